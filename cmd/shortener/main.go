@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
@@ -52,11 +53,23 @@ func main() {
 		serverLogger.Fatal(fmt.Sprintf("init config: %v", err))
 	}
 
+	// config.Get parses flags, so it must be called before positional args are checked.
+	cfg := config.Get()
+	if args := flag.Args(); len(args) > 0 && args[0] == migrateCommand {
+		if err = runMigrate(context.Background(), os.Stdout, cfg.Database, args[1:], srvLogger); err != nil {
+			srvLogger.Fatal(fmt.Sprintf("migrate: %v", err))
+		}
+		return
+	}
+
 	storage, err := initStorage(srvLogger)
 	if err != nil {
 		serverLogger.Fatal(fmt.Sprintf("init storage: %v", err))
 	}
-	srv := service.New(srvLogger, storage)
+	srv, err := service.New(srvLogger, storage)
+	if err != nil {
+		serverLogger.Fatal(fmt.Sprintf("init service: %v", err))
+	}
 	defer func() {
 		if err = srv.Close(); err != nil {
 			srvLogger.Error("close service", zap.Error(err))
@@ -157,6 +170,10 @@ func initStorage(logger *zap.Logger) (storage store.Store, err error) {
 
 	switch cfg.StorageType {
 	case store.InMemoryStorage:
+		if cfg.SnapshotPath != "" {
+			storage, err = inmemory.NewWithSnapshot(cfg.SnapshotPath, cfg.SnapshotInterval)
+			break
+		}
 		storage = inmemory.New()
 	case store.FileBasedStorage:
 		storage, err = filebased.New(cfg.FilePath)
@@ -165,6 +182,24 @@ func initStorage(logger *zap.Logger) (storage store.Store, err error) {
 	default:
 		storage = inmemory.New()
 	}
+	if err != nil {
+		return
+	}
+
+	scope, err := store.ParseDedupScope(cfg.DedupScope)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := storage.(interface {
+		SetDedupScope(store.DedupScope)
+	}); ok {
+		d.SetDedupScope(scope)
+	}
+	if s, ok := storage.(*sqlstore.SQLStore); ok {
+		if err = s.SyncDedupKeys(context.Background()); err != nil {
+			return nil, fmt.Errorf("sync dedup keys: %w", err)
+		}
+	}
 	return
 }
 
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"io"
 	"os"
 	"testing"
 
@@ -84,3 +86,25 @@ func TestInitStorage(t *testing.T) {
 		})
 	}
 }
+
+func TestRunMigrate_BadUsage(t *testing.T) {
+	l, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	tt := []struct {
+		name string
+		dsn  string
+		args []string
+	}{
+		{name: "no dsn", dsn: "", args: []string{"up"}},
+		{name: "no action", dsn: "postgres://localhost", args: nil},
+		{name: "too many args", dsn: "postgres://localhost", args: []string{"up", "down"}},
+		{name: "unknown action", dsn: "postgres://localhost", args: []string{"sideways"}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := runMigrate(context.Background(), io.Discard, tc.dsn, tc.args, l)
+			assert.ErrorIs(t, err, errBadMigrateUsage)
+		})
+	}
+}
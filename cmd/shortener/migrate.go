@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vlad-marlo/shortener/internal/store/sqlstore"
+)
+
+// migrateCommand is first positional argument which starts service in migrations mode:
+//
+//	shortener -d <dsn> migrate up|down|status
+const migrateCommand = "migrate"
+
+// errBadMigrateUsage ...
+var errBadMigrateUsage = errors.New("usage: shortener -d <dsn> migrate up|down|status")
+
+// runMigrate applies, rolls back or prints status of sql store migrations depending on action.
+func runMigrate(ctx context.Context, w io.Writer, dsn string, args []string, logger *zap.Logger) error {
+	if dsn == "" || len(args) != 1 {
+		return errBadMigrateUsage
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("open db: %w", err)
+	}
+	defer func() {
+		if err = db.Close(); err != nil {
+			logger.Warn("close db", zap.Error(err))
+		}
+	}()
+
+	m, err := sqlstore.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx)
+	case "status":
+		var statuses []*sqlstore.MigrationStatus
+		statuses, err = m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			appliedAt := "pending"
+			if st.Applied {
+				appliedAt = st.AppliedAt.Format(time.RFC3339)
+			}
+			if _, err = fmt.Fprintf(w, "%04d_%s\t%s\n", st.Version, st.Name, appliedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errBadMigrateUsage
+	}
+}
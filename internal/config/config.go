@@ -10,10 +10,13 @@ import (
 	"os"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 
+	"github.com/vlad-marlo/shortener/internal/domainpolicy"
 	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
 // Config ...
@@ -28,6 +31,36 @@ type Config struct {
 	GRPCAddr   string `env:"GRPC_ADDRESS" json:"grpc_address"`
 	TrustedIP  string `env:"TRUSTED_SUBNET" json:"trusted_subnet"`
 
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" json:"sweep_interval"`
+
+	SnapshotPath     string        `env:"SNAPSHOT_PATH" json:"snapshot_path"`
+	SnapshotInterval time.Duration `env:"SNAPSHOT_INTERVAL" json:"snapshot_interval"`
+
+	IDGenerator string `env:"ID_GENERATOR" json:"id_generator"`
+	IDLength    int    `env:"ID_LENGTH" json:"id_length"`
+	IDSalt      string `env:"ID_SALT" json:"id_salt"`
+
+	DedupScope string `env:"DEDUP_SCOPE" json:"dedup_scope"`
+	// NormalizeDropFragment makes urls which differ only in fragment same url, fragment is dropped from them.
+	NormalizeDropFragment bool `env:"NORMALIZE_DROP_FRAGMENT" json:"normalize_drop_fragment"`
+
+	// AllowedSchemes are schemes of original urls which may be shortened.
+	AllowedSchemes     []string `env:"ALLOWED_SCHEMES" envSeparator:"," json:"allowed_schemes"`
+	MaxURLLength       int      `env:"MAX_URL_LENGTH" json:"max_url_length"`
+	RejectPrivateHosts bool     `env:"REJECT_PRIVATE_HOSTS" json:"reject_private_hosts"`
+
+	// AliasDomains are domains which serve short urls besides domain of BaseURL. Original urls which point to
+	// them are short urls too.
+	AliasDomains []string `env:"ALIAS_DOMAINS" envSeparator:"," json:"alias_domains"`
+
+	// DomainPolicyFile is path to file with blocklist and allowlist of domains of original urls. File is
+	// reloaded on SIGHUP and when it is changed; it is checked for changes every DomainPolicyReloadInterval.
+	DomainPolicyFile           string        `env:"DOMAIN_POLICY_FILE" json:"domain_policy_file"`
+	DomainPolicyReloadInterval time.Duration `env:"DOMAIN_POLICY_RELOAD_INTERVAL" json:"domain_policy_reload_interval"`
+
+	PasswordAttempts       int           `env:"PASSWORD_ATTEMPTS" json:"password_attempts"`
+	PasswordAttemptsWindow time.Duration `env:"PASSWORD_ATTEMPTS_WINDOW" json:"password_attempts_window"`
+
 	StorageType string
 	IP          net.IP
 }
@@ -38,6 +71,19 @@ const defaultBindAddr = "localhost:8080"
 // defaultBaseURL ...
 const defaultBaseURL = "http://localhost:8080"
 
+// defaultSweepInterval ...
+const defaultSweepInterval = time.Minute
+
+// defaultSnapshotInterval ...
+const defaultSnapshotInterval = time.Minute
+
+// defaultPasswordAttempts is count of password attempts of one url which are allowed during
+// defaultPasswordAttemptsWindow.
+const defaultPasswordAttempts = 5
+
+// defaultPasswordAttemptsWindow ...
+const defaultPasswordAttemptsWindow = time.Minute
+
 var config *Config
 var once sync.Once
 
@@ -125,6 +171,54 @@ func (c *Config) parseFile() error {
 	if c.TrustedIP == "" {
 		c.TrustedIP = newConfig.TrustedIP
 	}
+	if c.SweepInterval == 0 {
+		c.SweepInterval = newConfig.SweepInterval
+	}
+	if c.SnapshotPath == "" {
+		c.SnapshotPath = newConfig.SnapshotPath
+	}
+	if c.SnapshotInterval == 0 {
+		c.SnapshotInterval = newConfig.SnapshotInterval
+	}
+	if c.IDGenerator == "" {
+		c.IDGenerator = newConfig.IDGenerator
+	}
+	if c.IDLength == 0 {
+		c.IDLength = newConfig.IDLength
+	}
+	if c.IDSalt == "" {
+		c.IDSalt = newConfig.IDSalt
+	}
+	if c.DedupScope == "" {
+		c.DedupScope = newConfig.DedupScope
+	}
+	if !c.NormalizeDropFragment {
+		c.NormalizeDropFragment = newConfig.NormalizeDropFragment
+	}
+	if len(c.AllowedSchemes) == 0 {
+		c.AllowedSchemes = newConfig.AllowedSchemes
+	}
+	if c.MaxURLLength == 0 {
+		c.MaxURLLength = newConfig.MaxURLLength
+	}
+	if !c.RejectPrivateHosts {
+		c.RejectPrivateHosts = newConfig.RejectPrivateHosts
+	}
+	if len(c.AliasDomains) == 0 {
+		c.AliasDomains = newConfig.AliasDomains
+	}
+	if c.DomainPolicyFile == "" {
+		c.DomainPolicyFile = newConfig.DomainPolicyFile
+	}
+	if c.DomainPolicyReloadInterval == 0 {
+		c.DomainPolicyReloadInterval = newConfig.DomainPolicyReloadInterval
+	}
+	if c.PasswordAttempts == 0 {
+		c.PasswordAttempts = newConfig.PasswordAttempts
+	}
+	if c.PasswordAttemptsWindow == 0 {
+		c.PasswordAttemptsWindow = newConfig.PasswordAttemptsWindow
+	}
 
 	return nil
 }
@@ -146,22 +240,59 @@ func (c *Config) setDefaultValues() {
 	if c.BaseURL == "" {
 		c.BaseURL = defaultBaseURL
 	}
+	if c.SweepInterval == 0 {
+		c.SweepInterval = defaultSweepInterval
+	}
+	if c.SnapshotInterval == 0 {
+		c.SnapshotInterval = defaultSnapshotInterval
+	}
+	if len(c.AllowedSchemes) == 0 {
+		c.AllowedSchemes = model.DefaultURLSchemes
+	}
+	if c.MaxURLLength == 0 {
+		c.MaxURLLength = model.DefaultURLMaxLength
+	}
+	if c.DomainPolicyReloadInterval == 0 {
+		c.DomainPolicyReloadInterval = domainpolicy.DefaultReloadInterval
+	}
+	if c.PasswordAttempts == 0 {
+		c.PasswordAttempts = defaultPasswordAttempts
+	}
+	if c.PasswordAttemptsWindow == 0 {
+		c.PasswordAttemptsWindow = defaultPasswordAttemptsWindow
+	}
 }
 
 // Copy returns Config object with same fields as parent config.
 func (c *Config) Copy() *Config {
 	return &Config{
-		ConfigFile:  c.ConfigFile,
-		BindAddr:    c.BindAddr,
-		BaseURL:     c.BaseURL,
-		FilePath:    c.FilePath,
-		Database:    c.Database,
-		HTTPS:       c.HTTPS,
-		GRPC:        c.GRPC,
-		GRPCAddr:    c.GRPCAddr,
-		TrustedIP:   c.TrustedIP,
-		StorageType: c.StorageType,
-		IP:          c.IP,
+		ConfigFile:                 c.ConfigFile,
+		BindAddr:                   c.BindAddr,
+		BaseURL:                    c.BaseURL,
+		FilePath:                   c.FilePath,
+		Database:                   c.Database,
+		HTTPS:                      c.HTTPS,
+		GRPC:                       c.GRPC,
+		GRPCAddr:                   c.GRPCAddr,
+		TrustedIP:                  c.TrustedIP,
+		SweepInterval:              c.SweepInterval,
+		SnapshotPath:               c.SnapshotPath,
+		SnapshotInterval:           c.SnapshotInterval,
+		IDGenerator:                c.IDGenerator,
+		IDLength:                   c.IDLength,
+		IDSalt:                     c.IDSalt,
+		DedupScope:                 c.DedupScope,
+		NormalizeDropFragment:      c.NormalizeDropFragment,
+		AllowedSchemes:             append([]string(nil), c.AllowedSchemes...),
+		MaxURLLength:               c.MaxURLLength,
+		RejectPrivateHosts:         c.RejectPrivateHosts,
+		AliasDomains:               append([]string(nil), c.AliasDomains...),
+		DomainPolicyFile:           c.DomainPolicyFile,
+		DomainPolicyReloadInterval: c.DomainPolicyReloadInterval,
+		PasswordAttempts:           c.PasswordAttempts,
+		PasswordAttemptsWindow:     c.PasswordAttemptsWindow,
+		StorageType:                c.StorageType,
+		IP:                         c.IP,
 	}
 }
 
@@ -0,0 +1,314 @@
+// Package domainpolicy contains blocklist and allowlist of domains of original urls.
+//
+// Policy is loaded from file with one rule per line:
+//
+//	# comment
+//	block example.com       # exact domain
+//	block *.phishing.test   # any subdomain of domain
+//	block /^login-[a-z]+\./ # regular expression which is matched against domain
+//	allow *.example.org
+//
+// Domain is blocked if it matches any block rule. If policy has allow rules, domain which matches none of
+// them is blocked too.
+package domainpolicy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/idna"
+)
+
+// const ...
+const (
+	// ActionBlock ...
+	ActionBlock = "block"
+	// ActionAllow ...
+	ActionAllow = "allow"
+
+	// DefaultReloadInterval is interval of policy file checks for changes.
+	DefaultReloadInterval = 10 * time.Second
+)
+
+// vars ...
+var (
+	// ErrBlocked is returned when domain of url is not allowed by policy.
+	ErrBlocked = errors.New("domain is blocked by policy")
+	// ErrBadRule ...
+	ErrBadRule = errors.New("bad rule of domain policy")
+)
+
+// BlockedError is error of url which domain is not allowed by policy. It keeps rule which blocked domain.
+type BlockedError struct {
+	// Domain ...
+	Domain string
+	// Rule is rule which blocked domain; it is empty if domain matched no allow rule.
+	Rule string
+}
+
+// Error ...
+func (e *BlockedError) Error() string {
+	if e.Rule == "" {
+		return fmt.Sprintf("domain %s is blocked by policy: it matches no allow rule", e.Domain)
+	}
+	return fmt.Sprintf("domain %s is blocked by policy rule %q", e.Domain, e.Rule)
+}
+
+// Is makes BlockedError match ErrBlocked.
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// rule is one line of policy file.
+type rule struct {
+	action  string
+	pattern string
+	line    int
+	re      *regexp.Regexp
+}
+
+// String returns rule as it is written in file with line number.
+func (r *rule) String() string {
+	return fmt.Sprintf("%s %s (line %d)", r.action, r.pattern, r.line)
+}
+
+// match reports whether domain matches rule.
+func (r *rule) match(domain string) bool {
+	switch {
+	case r.re != nil:
+		return r.re.MatchString(domain)
+	case strings.HasPrefix(r.pattern, "*."):
+		return strings.HasSuffix(domain, r.pattern[1:])
+	default:
+		return domain == r.pattern
+	}
+}
+
+// rules ...
+type rules struct {
+	block []*rule
+	allow []*rule
+}
+
+// check returns error if domain is not allowed by rules.
+func (rs *rules) check(domain string) error {
+	for _, r := range rs.block {
+		if r.match(domain) {
+			return &BlockedError{Domain: domain, Rule: r.String()}
+		}
+	}
+	if len(rs.allow) == 0 {
+		return nil
+	}
+	for _, r := range rs.allow {
+		if r.match(domain) {
+			return nil
+		}
+	}
+	return &BlockedError{Domain: domain}
+}
+
+// parse parses rules of policy file.
+func parse(data []byte) (*rules, error) {
+	rs := new(rules)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: %w: want action and pattern", n, ErrBadRule)
+		}
+		r, err := newRule(fields[0], fields[1], n)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if r.action == ActionBlock {
+			rs.block = append(rs.block, r)
+		} else {
+			rs.allow = append(rs.allow, r)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return rs, nil
+}
+
+// newRule ...
+func newRule(action, pattern string, line int) (*rule, error) {
+	action = strings.ToLower(action)
+	if action != ActionBlock && action != ActionAllow {
+		return nil, fmt.Errorf("%w: unknown action %q", ErrBadRule, action)
+	}
+	r := &rule{action: action, pattern: pattern, line: line}
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadRule, err)
+		}
+		r.re = re
+		return r, nil
+	}
+	wildcard := strings.HasPrefix(pattern, "*.")
+	domain := normalize(strings.TrimPrefix(pattern, "*."))
+	if domain == "" || strings.ContainsAny(domain, "*/") {
+		return nil, fmt.Errorf("%w: bad domain %q", ErrBadRule, pattern)
+	}
+	if wildcard {
+		domain = "*." + domain
+	}
+	r.pattern = domain
+	return r, nil
+}
+
+// normalize returns domain in lower case ascii form without trailing dot.
+func normalize(domain string) string {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
+
+// Policy is domain policy which is loaded from file. Policy is reloaded when process receives SIGHUP or
+// file is changed. Nil policy allows every domain.
+type Policy struct {
+	path   string
+	logger *zap.Logger
+
+	mu      sync.RWMutex
+	rules   *rules
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New loads policy from file. Call Watch to reload policy on changes.
+func New(path string, logger *zap.Logger) (*Policy, error) {
+	p := &Policy{
+		path:   path,
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload loads policy from file again. Current rules are kept if file is bad; bad file is not reloaded
+// again until it is changed.
+func (p *Policy) Reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("os: stat: %w", err)
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("os: read file: %w", err)
+	}
+	rs, err := parse(data)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", p.path, err)
+	}
+	p.rules = rs
+	return nil
+}
+
+// changed reports whether file of policy was changed after last load.
+func (p *Policy) changed() bool {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !info.ModTime().Equal(p.modTime) || info.Size() != p.size
+}
+
+// Watch reloads policy when process receives SIGHUP or file is changed; file is checked every interval.
+// Watching is stopped by Close.
+func (p *Policy) Watch(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer signal.Stop(hup)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-hup:
+				p.reload("sighup")
+			case <-ticker.C:
+				if p.changed() {
+					p.reload("file changed")
+				}
+			}
+		}
+	}()
+}
+
+// reload reloads policy and logs result.
+func (p *Policy) reload(reason string) {
+	if err := p.Reload(); err != nil {
+		p.logger.Error("reload domain policy", zap.String("reason", reason), zap.Error(err))
+		return
+	}
+	p.logger.Info("domain policy reloaded", zap.String("reason", reason), zap.String("path", p.path))
+}
+
+// Check returns BlockedError if domain of raw url is not allowed by policy. Urls without host are not
+// checked: they are rejected by url validation.
+func (p *Policy) Check(raw string) error {
+	if p == nil {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.rules.check(normalize(u.Hostname()))
+}
+
+// Close stops watching of policy.
+func (p *Policy) Close() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	p.wg.Wait()
+}
@@ -0,0 +1,141 @@
+package domainpolicy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writePolicy writes policy file into temp dir of test and returns its path.
+func writePolicy(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.txt")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "empty"},
+		{name: "comments", data: "# comment\n\n  # indented comment\n"},
+		{name: "all patterns", data: "block example.com\nBLOCK *.phishing.test # comment\nallow /^ok-/\n"},
+		{name: "unknown action", data: "deny example.com", wantErr: ErrBadRule},
+		{name: "no pattern", data: "block", wantErr: ErrBadRule},
+		{name: "extra field", data: "block example.com org", wantErr: ErrBadRule},
+		{name: "bad regexp", data: "block /[a-/", wantErr: ErrBadRule},
+		{name: "wildcard in middle", data: "block a.*.com", wantErr: ErrBadRule},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse([]byte(tc.data))
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p, err := New(writePolicy(t, `
+block evil.com
+block *.phishing.test
+block /^login-[a-z]+\./
+block пример.рф
+`), zap.NewNop())
+	require.NoError(t, err)
+
+	tt := []struct {
+		name string
+		url  string
+		rule string
+	}{
+		{name: "allowed", url: "https://example.com/path"},
+		{name: "exact", url: "https://evil.com/", rule: "block evil.com (line 2)"},
+		{name: "exact ignores case and port", url: "https://EVIL.com:8443/", rule: "block evil.com (line 2)"},
+		{name: "exact doesn't match subdomain", url: "https://www.evil.com/"},
+		{name: "wildcard", url: "http://a.b.phishing.test/", rule: "block *.phishing.test (line 3)"},
+		{name: "wildcard doesn't match domain", url: "http://phishing.test/"},
+		{name: "regexp", url: "https://login-bank.example/", rule: `block /^login-[a-z]+\./ (line 4)`},
+		{name: "idn", url: "https://xn--e1afmkfd.xn--p1ai/", rule: "block xn--e1afmkfd.xn--p1ai (line 5)"},
+		{name: "no host", url: "mailto:user@evil.com"},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.Check(tc.url)
+			if tc.rule == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrBlocked)
+			var blocked *BlockedError
+			require.True(t, errors.As(err, &blocked))
+			assert.Equal(t, tc.rule, blocked.Rule)
+		})
+	}
+}
+
+func TestPolicy_Check_Allowlist(t *testing.T) {
+	p, err := New(writePolicy(t, "allow *.example.com\nallow example.com\nblock bad.example.com\n"), zap.NewNop())
+	require.NoError(t, err)
+
+	assert.NoError(t, p.Check("https://example.com/"))
+	assert.NoError(t, p.Check("https://www.example.com/"))
+	// block rules win over allow rules.
+	assert.ErrorIs(t, p.Check("https://bad.example.com/"), ErrBlocked)
+
+	err = p.Check("https://example.org/")
+	var blocked *BlockedError
+	require.True(t, errors.As(err, &blocked))
+	assert.Empty(t, blocked.Rule)
+	assert.Equal(t, "example.org", blocked.Domain)
+}
+
+func TestPolicy_Check_Nil(t *testing.T) {
+	var p *Policy
+	assert.NoError(t, p.Check("https://evil.com/"))
+	p.Close()
+}
+
+func TestPolicy_Reload(t *testing.T) {
+	path := writePolicy(t, "block evil.com\n")
+	p, err := New(path, zap.NewNop())
+	require.NoError(t, err)
+	defer p.Close()
+	require.ErrorIs(t, p.Check("https://evil.com/"), ErrBlocked)
+	assert.False(t, p.changed())
+
+	require.NoError(t, os.WriteFile(path, []byte("block other.com\n"), 0o600))
+	assert.True(t, p.changed())
+	require.NoError(t, p.Reload())
+	assert.NoError(t, p.Check("https://evil.com/"))
+	assert.ErrorIs(t, p.Check("https://other.com/"), ErrBlocked)
+
+	// rules are kept if new file is bad.
+	require.NoError(t, os.WriteFile(path, []byte("deny evil.com\n"), 0o600))
+	assert.ErrorIs(t, p.Reload(), ErrBadRule)
+	assert.ErrorIs(t, p.Check("https://other.com/"), ErrBlocked)
+	assert.False(t, p.changed())
+}
+
+func TestPolicy_Watch(t *testing.T) {
+	path := writePolicy(t, "block evil.com\n")
+	p, err := New(path, zap.NewNop())
+	require.NoError(t, err)
+	p.Watch(10 * time.Millisecond)
+	defer p.Close()
+
+	require.NoError(t, os.WriteFile(path, []byte("block other.com\nblock more.com\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return p.Check("https://evil.com/") == nil
+	}, time.Second, 10*time.Millisecond)
+}
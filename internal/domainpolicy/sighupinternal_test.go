@@ -0,0 +1,31 @@
+//go:build unix
+
+package domainpolicy
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPolicy_Watch_SIGHUP(t *testing.T) {
+	path := writePolicy(t, "block evil.com\n")
+	p, err := New(path, zap.NewNop())
+	require.NoError(t, err)
+	// file isn't checked for changes during test, so only sighup reloads policy.
+	p.Watch(time.Hour)
+	defer p.Close()
+
+	require.NoError(t, os.WriteFile(path, []byte("block other.com\n"), 0o600))
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	assert.Eventually(t, func() bool {
+		return errors.Is(p.Check("https://other.com/"), ErrBlocked)
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, p.Check("https://evil.com/"))
+}
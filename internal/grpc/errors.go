@@ -1,8 +1,14 @@
 package grpc
 
 import (
+	"errors"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/vlad-marlo/shortener/internal/domainpolicy"
+	srv "github.com/vlad-marlo/shortener/internal/service"
+	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
 // Unauthenticated ...
@@ -25,6 +31,34 @@ func BadRequest() error {
 	return status.Error(codes.InvalidArgument, "bad request")
 }
 
+// validationError returns InvalidArgument status for validation error. Status of invalid original url has
+// message of url error, so client knows which check url failed. Url which is blocked by domain policy gets
+// PermissionDenied status with rule which blocked it.
+func validationError(err error) error {
+	if blockedErr := domainBlocked(err); blockedErr != nil {
+		return blockedErr
+	}
+	if urlErr := model.URLError(err); urlErr != nil {
+		return status.Error(codes.InvalidArgument, urlErr.Error())
+	}
+	for _, target := range []error{srv.ErrSelfReference, srv.ErrRedirectLoop} {
+		if errors.Is(err, target) {
+			return status.Error(codes.InvalidArgument, target.Error())
+		}
+	}
+	return BadRequest()
+}
+
+// domainBlocked returns PermissionDenied status with message of error if url is blocked by domain policy,
+// otherwise it returns nil.
+func domainBlocked(err error) error {
+	var blocked *domainpolicy.BlockedError
+	if errors.As(err, &blocked) {
+		return status.Error(codes.PermissionDenied, blocked.Error())
+	}
+	return nil
+}
+
 // Canceled ...
 func Canceled() error {
 	return status.Error(codes.Canceled, "canceled")
@@ -34,3 +68,13 @@ func Canceled() error {
 func PermissionDenied() error {
 	return status.Error(codes.PermissionDenied, "permission denied")
 }
+
+// AlreadyExists ...
+func AlreadyExists() error {
+	return status.Error(codes.AlreadyExists, "already exists")
+}
+
+// ResourceExhausted ...
+func ResourceExhausted() error {
+	return status.Error(codes.ResourceExhausted, "resource exhausted")
+}
@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/vlad-marlo/shortener/internal/domainpolicy"
 	srv "github.com/vlad-marlo/shortener/internal/service"
 	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
@@ -37,22 +41,161 @@ func (s *Server) Ping(ctx context.Context, _ *pb.PingRequest) (*pb.PingResponse,
 }
 
 // GetLink ...
+//
+// Password of request is required if link is protected with password.
 func (s *Server) GetLink(ctx context.Context, r *pb.GetLinkRequest) (*pb.GetLinkResponse, error) {
 	resp := new(pb.GetLinkResponse)
-	url, err := s.srv.GetByID(ctx, r.Id)
+	url, err := s.srv.OpenURL(ctx, r.Id, r.Password)
 	resp.Status = http.StatusOK
 	switch {
-	case errors.Is(err, store.ErrIsDeleted):
+	case errors.Is(err, srv.ErrPasswordRequired), errors.Is(err, srv.ErrWrongPassword):
+		return nil, PermissionDenied()
+	case errors.Is(err, srv.ErrTooManyAttempts):
+		return nil, ResourceExhausted()
+	case errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired), errors.Is(err, store.ErrExhausted):
 		resp.Status = http.StatusGone
+		return resp, nil
+	case errors.Is(err, srv.ErrRedirectLoop):
+		resp.Status = http.StatusLoopDetected
+		return resp, nil
 	case errors.Is(err, store.ErrNotFound):
 		return nil, NotFound()
+	case errors.Is(err, domainpolicy.ErrBlocked):
+		return nil, domainBlocked(err)
 	case err != nil:
 		s.logger.Error("grpc: get link", zap.Error(err))
 		resp.Status = http.StatusInternalServerError
 		return nil, Internal()
 	}
 
-	resp.Location = url.BaseURL
+	click := s.clickFromCtx(ctx, url.ID)
+	target, variant := url.Target(visitorFromCtx(ctx, r.Variant))
+	resp.Location = url.Location(target, r.Query)
+	if variant != nil {
+		click.Variant = variant.Name
+		resp.Variant = variant.Name
+	}
+	s.srv.RecordClick(click)
+
+	resp.Status = uint32(url.Redirect())
+	return resp, nil
+}
+
+// clickFromCtx returns click on url with data from request metadata and peer.
+func (s *Server) clickFromCtx(ctx context.Context, id string) *model.Click {
+	c := &model.Click{
+		URLID: id,
+		Time:  time.Now().UTC(),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("referer"); len(v) > 0 {
+			c.Referer = v[0]
+		}
+		if v := md.Get("user-agent"); len(v) > 0 {
+			c.UserAgent = v[0]
+		}
+		if v := md.Get("x-real-ip"); len(v) > 0 {
+			c.IP = v[0]
+		}
+	}
+	if ip, ok := peerIP(ctx); ok && c.IP == "" {
+		c.IP = ip
+	}
+	return c
+}
+
+// GetLinkStats ...
+func (s *Server) GetLinkStats(ctx context.Context, r *pb.GetLinkStatsRequest) (*pb.GetLinkStatsResponse, error) {
+	user, err := s.getUser(r)
+	if err != nil {
+		return nil, Unauthenticated()
+	}
+	stats, err := s.srv.GetLinkStats(ctx, user, r.Id)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return nil, NotFound()
+	case errors.Is(err, srv.ErrForbidden):
+		return nil, PermissionDenied()
+	case err != nil:
+		s.logger.Error("grpc: get link stats", zap.Error(err))
+		return nil, Internal()
+	}
+
+	resp := &pb.GetLinkStatsResponse{
+		Id:    stats.ID,
+		Total: stats.Total,
+	}
+	for _, d := range stats.Daily {
+		resp.Daily = append(resp.Daily, &pb.GetLinkStatsResponse_Day{
+			Date:  d.Date,
+			Count: d.Count,
+		})
+	}
+	for _, v := range stats.Variants {
+		resp.Variants = append(resp.Variants, &pb.GetLinkStatsResponse_Variant{
+			Name:  v.Name,
+			Count: v.Count,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateLink changes destination and redirect code of link. Only user which created link is able to
+// change it.
+func (s *Server) UpdateLink(ctx context.Context, r *pb.UpdateLinkRequest) (*pb.UpdateLinkResponse, error) {
+	user, err := s.getUser(r)
+	if err != nil {
+		return nil, Unauthenticated()
+	}
+	opts, err := optionsOf(r)
+	if err != nil {
+		return nil, BadRequest()
+	}
+	u, err := s.srv.UpdateURL(ctx, user, r.Id, r.Url, opts...)
+	switch {
+	case errors.Is(err, store.ErrNotFound), errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired):
+		return nil, NotFound()
+	case errors.Is(err, srv.ErrForbidden):
+		return nil, PermissionDenied()
+	case errors.Is(err, store.ErrAlreadyExists):
+		return nil, AlreadyExists()
+	case isValidationErr(err):
+		return nil, validationError(err)
+	case err != nil:
+		s.logger.Error("grpc: update link", zap.Error(err))
+		return nil, Internal()
+	}
+	return &pb.UpdateLinkResponse{
+		ShortUrl:     u.ShortURL,
+		OriginalUrl:  u.OriginalURL,
+		UpdatedAt:    u.UpdatedAt.Format(time.RFC3339Nano),
+		RedirectCode: uint32(u.RedirectCode),
+	}, nil
+}
+
+// GetLinkHistory returns previous destinations of link from oldest to newest.
+func (s *Server) GetLinkHistory(ctx context.Context, r *pb.GetLinkHistoryRequest) (*pb.GetLinkHistoryResponse, error) {
+	user, err := s.getUser(r)
+	if err != nil {
+		return nil, Unauthenticated()
+	}
+	history, err := s.srv.GetHistory(ctx, user, r.Id)
+	switch {
+	case errors.Is(err, store.ErrNotFound), errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired):
+		return nil, NotFound()
+	case errors.Is(err, srv.ErrForbidden):
+		return nil, PermissionDenied()
+	case err != nil:
+		s.logger.Error("grpc: get link history", zap.Error(err))
+		return nil, Internal()
+	}
+	resp := new(pb.GetLinkHistoryResponse)
+	for _, d := range history {
+		resp.History = append(resp.History, &pb.GetLinkHistoryResponse_Destination{
+			OriginalUrl: d.OriginalURL,
+			ReplacedAt:  d.ReplacedAt.Format(time.RFC3339Nano),
+		})
+	}
 	return resp, nil
 }
 
@@ -65,9 +208,15 @@ func (s *Server) CreateLinkJSON(ctx context.Context, r *pb.CreateLinkJSONRequest
 		return nil, Unauthenticated()
 	}
 
+	opts, err := optionsOf(r)
+	if err != nil {
+		return nil, BadRequest()
+	}
 	var u *model.URL
-	u, err = s.srv.CreateURL(ctx, user, r.Url)
-	if errors.Is(err, store.ErrAlreadyExists) {
+	u, err = s.srv.CreateURL(ctx, user, r.Url, opts...)
+	if isValidationErr(err) {
+		return nil, validationError(err)
+	} else if errors.Is(err, store.ErrAlreadyExists) {
 		resp.Status = http.StatusConflict
 	} else if err != nil {
 		return nil, Internal()
@@ -95,7 +244,11 @@ func (s *Server) CreateManyLinks(ctx context.Context, r *pb.CreateManyRequest) (
 
 	var urls []model.URLer
 	for _, u := range r.Urls {
-		urls = append(urls, u)
+		b, err := newBatchURL(u)
+		if err != nil {
+			return nil, BadRequest()
+		}
+		urls = append(urls, b)
 	}
 
 	var res []*model.BatchCreateURLsResponse
@@ -104,6 +257,9 @@ func (s *Server) CreateManyLinks(ctx context.Context, r *pb.CreateManyRequest) (
 		if errors.Is(err, context.Canceled) {
 			return nil, Canceled()
 		}
+		if isValidationErr(err) {
+			return nil, validationError(err)
+		}
 		return nil, Internal()
 	}
 	for _, b := range res {
@@ -125,8 +281,16 @@ func (s *Server) CreateLink(ctx context.Context, r *pb.CreateLinkRequest) (*pb.C
 
 	user, _ := s.getUser(r)
 
-	u, err := s.srv.CreateURL(ctx, user, r.Url)
-	if errors.Is(err, store.ErrAlreadyExists) {
+	opts, err := optionsOf(r)
+	if err != nil {
+		return nil, BadRequest()
+	}
+	u, err := s.srv.CreateURL(ctx, user, r.Url, opts...)
+	if errors.Is(err, store.ErrAliasTaken) {
+		return nil, AlreadyExists()
+	} else if isValidationErr(err) {
+		return nil, validationError(err)
+	} else if errors.Is(err, store.ErrAlreadyExists) {
 		resp.Status = http.StatusConflict
 	} else if err != nil {
 		resp.Status = http.StatusInternalServerError
@@ -151,10 +315,18 @@ func (s *Server) GetUser(context.Context, *pb.GetUserRequest) (*pb.GetUserRespon
 func (s *Server) GetManyLinks(ctx context.Context, r *pb.GetManyLinksRequest) (*pb.GetManyLinksResponse, error) {
 	var resp pb.GetManyLinksResponse
 	user, _ := s.getUser(r)
-	urls, err := s.srv.GetAllURLsByUser(ctx, user)
+	opts, err := store.NewListOptions(int(r.Limit), r.Cursor, r.Query, r.CreatedFrom, r.CreatedTo, r.Tag, r.Sort)
 	if err != nil {
+		return nil, BadRequest()
+	}
+	urls, next, err := s.srv.ListURLsByUser(ctx, user, opts)
+	switch {
+	case errors.Is(err, store.ErrBadListOptions):
+		return nil, BadRequest()
+	case err != nil:
 		return nil, Internal()
 	}
+	resp.NextCursor = next
 
 	if len(urls) == 0 {
 		resp.Status = http.StatusNoContent
@@ -162,10 +334,30 @@ func (s *Server) GetManyLinks(ctx context.Context, r *pb.GetManyLinksRequest) (*
 	}
 
 	for _, u := range urls {
-		resp.Urls = append(resp.Urls, &pb.GetManyLinksResponse_URL{
-			OriginalUrl: u.OriginalURL,
-			ShortUrl:    u.ShortURL,
-		})
+		url := &pb.GetManyLinksResponse_URL{
+			OriginalUrl:  u.OriginalURL,
+			CanonicalUrl: u.CanonicalURL,
+			ShortUrl:     u.ShortURL,
+			CreatedAt:    u.CreatedAt.Format(time.RFC3339Nano),
+			UpdatedAt:    u.UpdatedAt.Format(time.RFC3339Nano),
+			Title:        u.Title,
+			Note:         u.Note,
+			Tags:         u.Tags,
+			RedirectCode: uint32(u.RedirectCode),
+			Protected:    u.Protected,
+			MaxClicks:    u.MaxClicks,
+			Rules:        rulesToProto(u.Rules),
+			Variants:     variantsToProto(u.Variants),
+			Passthrough:  u.Passthrough,
+			Utm:          utmToProto(u.UTM),
+		}
+		if u.ClicksLeft != nil {
+			url.ClicksLeft = *u.ClicksLeft
+		}
+		if u.DeletedAt != nil {
+			url.DeletedAt = u.DeletedAt.Format(time.RFC3339Nano)
+		}
+		resp.Urls = append(resp.Urls, url)
 	}
 
 	return &resp, nil
@@ -182,6 +374,91 @@ func (s *Server) DeleteMany(_ context.Context, r *pb.DeleteManyRequest) (*pb.Del
 	return &resp, nil
 }
 
+// RestoreMany restores deleted urls of user.
+func (s *Server) RestoreMany(ctx context.Context, r *pb.RestoreManyRequest) (*pb.RestoreManyResponse, error) {
+	var resp pb.RestoreManyResponse
+	u, err := s.getUser(r)
+	if err != nil {
+		return nil, Unauthenticated()
+	}
+	n, err := s.srv.RestoreManyURLs(ctx, u, r.Ids)
+	switch {
+	case errors.Is(err, srv.ErrForbidden):
+		return nil, PermissionDenied()
+	case err != nil:
+		return nil, Internal()
+	}
+	resp.Status = http.StatusOK
+	resp.Restored = n
+	return &resp, nil
+}
+
+// PurgeDeleted physically removes urls which were deleted more than r.Days days ago. Only peers from
+// trusted subnet have access to it.
+func (s *Server) PurgeDeleted(ctx context.Context, r *pb.PurgeDeletedRequest) (*pb.PurgeDeletedResponse, error) {
+	ip, ok := peerIP(ctx)
+	if !ok {
+		return nil, PermissionDenied()
+	}
+	n, err := s.srv.PurgeDeleted(ctx, ip, time.Duration(r.Days)*24*time.Hour)
+	if err != nil {
+		if errors.Is(err, srv.ErrForbidden) {
+			return nil, PermissionDenied()
+		}
+		return nil, Internal()
+	}
+	return &pb.PurgeDeletedResponse{Purged: n}, nil
+}
+
+// peerIP returns ip address of peer without port.
+func peerIP(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String(), true
+	}
+	return host, true
+}
+
+// isValidationErr returns true if err is caused by invalid user input.
+func isValidationErr(err error) bool {
+	if model.URLError(err) != nil {
+		return true
+	}
+	for _, target := range []error{
+		model.ErrAliasBadLength,
+		model.ErrAliasBadCharset,
+		model.ErrAliasReserved,
+		model.ErrExpirationAmbiguous,
+		model.ErrExpirationBadFormat,
+		model.ErrExpirationInPast,
+		model.ErrTitleTooLong,
+		model.ErrNoteTooLong,
+		model.ErrTagBad,
+		model.ErrTooManyTags,
+		model.ErrRedirectCodeBad,
+		model.ErrPasswordTooLong,
+		model.ErrMaxClicksNegative,
+		model.ErrRuleBad,
+		model.ErrTooManyRules,
+		model.ErrVariantBad,
+		model.ErrTooManyVariants,
+		model.ErrUTMEmpty,
+		model.ErrUTMTooLong,
+		domainpolicy.ErrBlocked,
+		srv.ErrSelfReference,
+		srv.ErrRedirectLoop,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // getUser ...
 func (s *Server) getUser(r UserGetter) (res string, err error) {
 	if err = encryptor.Get().DecodeUUID(r.GetUser(), &res); err != nil {
@@ -191,11 +468,11 @@ func (s *Server) getUser(r UserGetter) (res string, err error) {
 }
 
 func (s *Server) GetInternalStats(ctx context.Context, r *pb.GetInternalStatsRequest) (*pb.GetInternalStatsResponse, error) {
-	p, ok := peer.FromContext(ctx)
+	ip, ok := peerIP(ctx)
 	if !ok {
 		return nil, PermissionDenied()
 	}
-	stat, err := s.srv.GetInternalStats(ctx, p.Addr.String())
+	stat, err := s.srv.GetInternalStats(ctx, ip)
 	if err != nil {
 		if errors.Is(err, srv.ErrForbidden) {
 			return nil, PermissionDenied()
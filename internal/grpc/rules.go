@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+	pb "github.com/vlad-marlo/shortener/pkg/proto"
+)
+
+// batchURL is url of batch request with targeting rules, split variants and utm template which are converted
+// to model, so options of url are provided by model.OptionsOf.
+type batchURL struct {
+	*pb.CreateManyRequest_URL
+	rules    []*model.Rule
+	variants []*model.Variant
+	utm      *model.UTM
+}
+
+// GetRules ...
+func (b *batchURL) GetRules() []*model.Rule {
+	return b.rules
+}
+
+// GetVariants ...
+func (b *batchURL) GetVariants() []*model.Variant {
+	return b.variants
+}
+
+// GetUtm ...
+func (b *batchURL) GetUtm() *model.UTM {
+	return b.utm
+}
+
+// newBatchURL ...
+func newBatchURL(u *pb.CreateManyRequest_URL) (*batchURL, error) {
+	rules, err := rulesFromProto(u.GetRules())
+	if err != nil {
+		return nil, err
+	}
+	return &batchURL{
+		CreateManyRequest_URL: u,
+		rules:                 rules,
+		variants:              variantsFromProto(u.GetVariants()),
+		utm:                   utmFromProto(u.GetUtm()),
+	}, nil
+}
+
+// optionsOf returns options of request including targeting rules, split variants and utm template.
+func optionsOf(r interface {
+	GetRules() []*pb.Rule
+	GetVariants() []*pb.Variant
+	GetUtm() *pb.UTM
+}) ([]model.URLOption, error) {
+	opts := model.OptionsOf(r)
+	if len(r.GetRules()) != 0 {
+		rules, err := rulesFromProto(r.GetRules())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, model.WithRules(rules))
+	}
+	if len(r.GetVariants()) != 0 {
+		opts = append(opts, model.WithVariants(variantsFromProto(r.GetVariants())))
+	}
+	if r.GetUtm() != nil {
+		opts = append(opts, model.WithUTM(utmFromProto(r.GetUtm())))
+	}
+	return opts, nil
+}
+
+// rulesFromProto converts targeting rules of request to model. Times of rules must be in RFC 3339 format.
+func rulesFromProto(rules []*pb.Rule) ([]*model.Rule, error) {
+	res := make([]*model.Rule, 0, len(rules))
+	for i, r := range rules {
+		rule := &model.Rule{
+			Device:      r.GetDevice(),
+			Languages:   r.GetLanguages(),
+			Destination: r.GetDestination(),
+		}
+		for _, t := range []struct {
+			value string
+			dst   **time.Time
+		}{{r.GetFrom(), &rule.From}, {r.GetTo(), &rule.To}} {
+			if t.value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, t.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w %d: times must be in RFC 3339 format", model.ErrRuleBad, i)
+			}
+			*t.dst = &parsed
+		}
+		res = append(res, rule)
+	}
+	return res, nil
+}
+
+// rulesToProto converts targeting rules of url to proto.
+func rulesToProto(rules []*model.Rule) []*pb.Rule {
+	res := make([]*pb.Rule, 0, len(rules))
+	for _, r := range rules {
+		rule := &pb.Rule{
+			Device:      r.Device,
+			Languages:   r.Languages,
+			Destination: r.Destination,
+		}
+		if r.From != nil {
+			rule.From = r.From.Format(time.RFC3339)
+		}
+		if r.To != nil {
+			rule.To = r.To.Format(time.RFC3339)
+		}
+		res = append(res, rule)
+	}
+	return res
+}
+
+// visitorFromCtx returns visitor of link with data from request metadata and split variant which was
+// chosen for visitor before.
+func visitorFromCtx(ctx context.Context, variant string) *model.Visitor {
+	v := &model.Visitor{Time: time.Now().UTC(), Variant: variant}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			v.UserAgent = ua[0]
+		}
+		if lang := md.Get("accept-language"); len(lang) > 0 {
+			v.AcceptLanguage = lang[0]
+		}
+	}
+	return v
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"net"
+	"time"
 
 	grpc_mw "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -11,19 +12,26 @@ import (
 	_ "google.golang.org/grpc/encoding/gzip"
 
 	"github.com/vlad-marlo/shortener/internal/config"
+	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
 	pb "github.com/vlad-marlo/shortener/pkg/proto"
 )
 
 type service interface {
 	Ping(ctx context.Context) error
-	CreateURL(ctx context.Context, user, url string) (*model.URL, error)
+	CreateURL(ctx context.Context, user, url string, opts ...model.URLOption) (*model.URL, error)
 	DeleteManyURLs(user string, urls []string)
-	GetAllURLsByUser(ctx context.Context, user string) ([]*model.AllUserURLsResponse, error)
-	NewURL(url, user string, correlationID ...string) (*model.URL, error)
+	ListURLsByUser(ctx context.Context, user string, opts store.ListOptions) ([]*model.AllUserURLsResponse, string, error)
+	NewURL(ctx context.Context, url, user string, correlationID ...string) (*model.URL, error)
 	CreateManyURLs(ctx context.Context, user string, urls []model.URLer) ([]*model.BatchCreateURLsResponse, error)
-	GetByID(ctx context.Context, id string) (*model.URL, error)
+	OpenURL(ctx context.Context, id, password string) (*model.URL, error)
 	GetInternalStats(ctx context.Context, ip string) (*model.InternalStat, error)
+	RecordClick(c *model.Click)
+	GetLinkStats(ctx context.Context, user, id string) (*model.LinkStats, error)
+	RestoreManyURLs(ctx context.Context, user string, ids []string) (int64, error)
+	UpdateURL(ctx context.Context, user, id, url string, opts ...model.URLOption) (*model.AllUserURLsResponse, error)
+	GetHistory(ctx context.Context, user, id string) ([]*model.Destination, error)
+	PurgeDeleted(ctx context.Context, ip string, olderThan time.Duration) (int64, error)
 }
 
 // Server is grpc Server
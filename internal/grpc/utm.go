@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"github.com/vlad-marlo/shortener/internal/store/model"
+	pb "github.com/vlad-marlo/shortener/pkg/proto"
+)
+
+// utmFromProto converts utm template of request to model. Nil template is returned if utm isn't provided.
+func utmFromProto(utm *pb.UTM) *model.UTM {
+	if utm == nil {
+		return nil
+	}
+	return &model.UTM{
+		Source:   utm.GetSource(),
+		Medium:   utm.GetMedium(),
+		Campaign: utm.GetCampaign(),
+		Term:     utm.GetTerm(),
+		Content:  utm.GetContent(),
+	}
+}
+
+// utmToProto converts utm template of url to proto.
+func utmToProto(utm *model.UTM) *pb.UTM {
+	if utm == nil {
+		return nil
+	}
+	return &pb.UTM{
+		Source:   utm.Source,
+		Medium:   utm.Medium,
+		Campaign: utm.Campaign,
+		Term:     utm.Term,
+		Content:  utm.Content,
+	}
+}
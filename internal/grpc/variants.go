@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"github.com/vlad-marlo/shortener/internal/store/model"
+	pb "github.com/vlad-marlo/shortener/pkg/proto"
+)
+
+// variantsFromProto converts split variants of request to model.
+func variantsFromProto(variants []*pb.Variant) []*model.Variant {
+	res := make([]*model.Variant, 0, len(variants))
+	for _, v := range variants {
+		res = append(res, &model.Variant{
+			Name:        v.GetName(),
+			Destination: v.GetDestination(),
+			Weight:      int(v.GetWeight()),
+		})
+	}
+	return res
+}
+
+// variantsToProto converts split variants of url to proto.
+func variantsToProto(variants []*model.Variant) []*pb.Variant {
+	res := make([]*pb.Variant, 0, len(variants))
+	for _, v := range variants {
+		res = append(res, &pb.Variant{
+			Name:        v.Name,
+			Destination: v.Destination,
+			Weight:      uint32(v.Weight),
+		})
+	}
+	return res
+}
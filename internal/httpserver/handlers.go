@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,6 +19,8 @@ import (
 )
 
 // handleURLGet is redirecting user to base url with id which is provided in url path by chi url params.
+//
+// If url is protected with password, handler will return html form which sends password to handleURLUnlock.
 func (s *Server) handleURLGet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	reqID := middleware.GetReqID(ctx)
@@ -28,27 +32,153 @@ func (s *Server) handleURLGet(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	id := chi.URLParam(r, "id")
 
-	url, err := s.srv.GetByID(ctx, id)
+	url, err := s.srv.OpenURL(ctx, id, "")
 	switch {
-	case errors.Is(err, store.ErrIsDeleted):
-		w.WriteHeader(http.StatusGone)
+	case errors.Is(err, srv.ErrPasswordRequired):
+		s.writePasswordForm(w, http.StatusOK, "", fields)
+		return
+	case s.handleOpenURLError(w, err, fields):
 		return
+	}
+
+	s.redirect(w, r, url, url.Redirect())
+}
+
+// handleURLUnlock is http handler which redirects user to base url of url which is protected with password.
+// Password is provided with password field of form.
+//
+// If password is wrong handler will return form again with http status 403, if too many attempts of url
+// password were done recently handler will return http status 429.
+func (s *Server) handleURLUnlock(w http.ResponseWriter, r *http.Request) {
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", r.Header.Get("X-Real-IP")),
+	}
+	w.Header().Set("Content-Type", "text/plain")
+
+	url, err := s.srv.OpenURL(r.Context(), chi.URLParam(r, "id"), r.PostFormValue("password"))
+	switch {
+	case errors.Is(err, srv.ErrPasswordRequired), errors.Is(err, srv.ErrWrongPassword):
+		s.logger.Debug(err.Error(), fields...)
+		s.writePasswordForm(w, http.StatusForbidden, "Wrong password.", fields)
+		return
+	case errors.Is(err, srv.ErrTooManyAttempts):
+		s.logger.Debug(err.Error(), fields...)
+		s.writePasswordForm(w, http.StatusTooManyRequests, "Too many attempts, try again later.", fields)
+		return
+	case s.handleOpenURLError(w, err, fields):
+		return
+	}
+
+	// redirect code of url is not used: 307 and 308 would send password to original url with repeated post.
+	s.redirect(w, r, url, http.StatusSeeOther)
+}
+
+// handleGetLinkStats is http handler which returns total and per-day count of clicks on url.
+//
+// Only user which created url have access to its stats.
+func (s *Server) handleGetLinkStats(w http.ResponseWriter, r *http.Request) {
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", r.Header.Get("X-Real-IP")),
+	}
+	userID := getUserFromRequest(r)
+
+	stats, err := s.srv.GetLinkStats(r.Context(), userID, chi.URLParam(r, "id"))
+	switch {
 	case errors.Is(err, store.ErrNotFound):
-		s.handleErrorOrStatus(w, errors.New("where is no url with that id"), fields, http.StatusNotFound)
+		s.handleErrorOrStatus(w, err, fields, http.StatusNotFound)
 		return
-	case err != nil:
-		s.handleErrorOrStatus(w, fmt.Errorf("internal: %w", err), fields, http.StatusInternalServerError)
+	case errors.Is(err, srv.ErrForbidden):
+		s.handleErrorOrStatus(w, err, fields, http.StatusForbidden)
+		return
+	case s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError):
+		return
+	}
+
+	data, err := json.Marshal(stats)
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
+}
+
+// handleURLUpdate is http handler which changes destination and redirect code of url. Request must be json
+// object with url and/or redirect_code fields. Previous destination is kept in history of url.
+//
+// Only user which created url is able to change it; if new destination is already shortened by other url
+// handler will return http status 409.
+func (s *Server) handleURLUpdate(w http.ResponseWriter, r *http.Request) {
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", r.Header.Get("X-Real-IP")),
+	}
+	userID := getUserFromRequest(r)
+
+	data := &model.UpdateURLRequest{}
+	if err := json.NewDecoder(r.Body).Decode(data); s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
 		return
 	}
 
-	w.Header().Set("Location", url.BaseURL)
-	w.WriteHeader(http.StatusTemporaryRedirect)
+	u, err := s.srv.UpdateURL(r.Context(), userID, chi.URLParam(r, "id"), data.URL, model.OptionsOf(data)...)
+	if s.handleURLAccessError(w, err, fields) {
+		return
+	}
+	if errors.Is(err, store.ErrAlreadyExists) {
+		s.handleErrorOrStatus(w, err, fields, http.StatusConflict)
+		return
+	}
+	if s.handleURLValidationError(w, err, fields) || s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
+		return
+	}
+
+	res, err := json.Marshal(u)
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(res)
+	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
+}
+
+// handleGetURLHistory is http handler which returns previous destinations of url from oldest to newest.
+// Only user which created url has access to its history.
+func (s *Server) handleGetURLHistory(w http.ResponseWriter, r *http.Request) {
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", r.Header.Get("X-Real-IP")),
+	}
+	userID := getUserFromRequest(r)
+
+	history, err := s.srv.GetHistory(r.Context(), userID, chi.URLParam(r, "id"))
+	if s.handleURLAccessError(w, err, fields) || s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+	if len(history) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	res, err := json.Marshal(history)
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(res)
+	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
 }
 
 // handleURLCreate is http handler which creates record about url and return
 // short link to url in response.
 //
 // If url was already registered when handler will return old value.
+// Expiration of url may be provided with expires_at or ttl query params.
 func (s *Server) handleURLCreate(w http.ResponseWriter, r *http.Request) {
 	// setting up response meta info
 	fields := []zap.Field{
@@ -75,7 +205,20 @@ func (s *Server) handleURLCreate(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserFromRequest(r)
 
-	u, err := s.srv.CreateURL(r.Context(), userID, string(data))
+	var ttl int64
+	if rawTTL := r.URL.Query().Get("ttl"); rawTTL != "" {
+		ttl, err = strconv.ParseInt(rawTTL, 10, 64)
+		if s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
+			return
+		}
+	}
+
+	u, err := s.srv.CreateURL(
+		r.Context(),
+		userID,
+		string(data),
+		model.WithExpiration(r.URL.Query().Get("expires_at"), ttl),
+	)
 	switch {
 	case errors.Is(err, store.ErrAlreadyExists):
 		w.WriteHeader(http.StatusConflict)
@@ -83,6 +226,8 @@ func (s *Server) handleURLCreate(w http.ResponseWriter, r *http.Request) {
 
 		s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
 		return
+	case s.handleURLValidationError(w, err, fields):
+		return
 	case err != nil:
 		s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest)
 		return
@@ -98,6 +243,8 @@ func (s *Server) handleURLCreate(w http.ResponseWriter, r *http.Request) {
 // short link to url in response.
 //
 // If url was already registered when handler will return old value.
+// Optional alias field of request will be used as short id; if alias is taken handler will return http status 409.
+// Expiration of url may be provided with expires_at (RFC 3339) or ttl (seconds) fields.
 func (s *Server) handleURLCreateJSON(w http.ResponseWriter, r *http.Request) {
 	fields := []zap.Field{
 		zap.String("request_id", middleware.GetReqID(r.Context())),
@@ -116,18 +263,23 @@ func (s *Server) handleURLCreateJSON(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserFromRequest(r)
 
-	u := &model.URL{}
-	if err = json.Unmarshal(req, u); s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+	data := &model.CreateURLRequest{}
+	if err = json.Unmarshal(req, data); s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
 		return
 	}
 
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
-	u, err = s.srv.CreateURL(ctx, userID, u.BaseURL)
+	u, err := s.srv.CreateURL(ctx, userID, data.URL, model.OptionsOf(data)...)
 	switch {
+	case errors.Is(err, store.ErrAliasTaken):
+		s.handleErrorOrStatus(w, err, fields, http.StatusConflict)
+		return
 	case errors.Is(err, store.ErrAlreadyExists):
 		w.WriteHeader(http.StatusConflict)
+	case s.handleURLValidationError(w, err, fields):
+		return
 	case s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest):
 		return
 	default:
@@ -146,7 +298,11 @@ func (s *Server) handleURLCreateJSON(w http.ResponseWriter, r *http.Request) {
 	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
 }
 
-// handleGetUserURLs is http handler which return to user all records which was created by him.
+// handleGetUserURLs is http handler which return to user page of records which was created by him.
+//
+// Page is configured with query params: limit, cursor, q (substring of original url), created_from and
+// created_to (RFC 3339), tag and sort (created_at, original_url or same with '-' prefix for descending order).
+// Cursor of next page is returned in X-Next-Cursor header if page is not last.
 func (s *Server) handleGetUserURLs(w http.ResponseWriter, r *http.Request) {
 	fields := []zap.Field{
 		zap.String("request_id", middleware.GetReqID(r.Context())),
@@ -156,7 +312,15 @@ func (s *Server) handleGetUserURLs(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	urls, err := s.srv.GetAllURLsByUser(ctx, userID)
+	opts, err := listOptionsFromQuery(r)
+	if s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
+		return
+	}
+	urls, next, err := s.srv.ListURLsByUser(ctx, userID, opts)
+	if errors.Is(err, store.ErrBadListOptions) {
+		s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest)
+		return
+	}
 	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
 		return
 	}
@@ -171,6 +335,9 @@ func (s *Server) handleGetUserURLs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if next != "" {
+		w.Header().Set(nextCursorHeader, next)
+	}
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write(response)
 	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
@@ -199,6 +366,7 @@ func (s *Server) handlePingStore(w http.ResponseWriter, r *http.Request) {
 // [{ "correlation_id": "1", "original_url": "https://ya.ru" }]
 // after creation in success case response will be like
 // [{ "correlation_id": "1", "short_url": "http://<server_addr>/<id>"}].
+// Every element may also contain expires_at or ttl fields.
 func (s *Server) handleURLBulkCreate(w http.ResponseWriter, r *http.Request) {
 	fields := []zap.Field{
 		zap.String("request_id", middleware.GetReqID(r.Context())),
@@ -229,7 +397,7 @@ func (s *Server) handleURLBulkCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := s.srv.CreateManyURLs(r.Context(), userID, urls)
-	if s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
+	if s.handleURLValidationError(w, err, fields) || s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
 		return
 	}
 
@@ -277,6 +445,88 @@ func (s *Server) handleURLBulkDelete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
+// handleURLBulkRestore is http handler which gives user access to restore deleted urls
+// which was created by him.
+//
+// Request must be json array of strings where every element is url id. Response contains count of restored urls.
+func (s *Server) handleURLBulkRestore(w http.ResponseWriter, r *http.Request) {
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", r.Header.Get("X-Real-IP")),
+	}
+	var data []string
+	userID := getUserFromRequest(r)
+
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			s.logger.Error(fmt.Sprintf("defering request body close: %v ", err), fields...)
+		}
+	}()
+
+	body, err := io.ReadAll(r.Body)
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+
+	if err = json.Unmarshal(body, &data); err != nil {
+		s.handleErrorOrStatus(w, fmt.Errorf("handle bulk url restore: json unmarshal data: %w", err), fields, http.StatusBadRequest)
+		return
+	}
+
+	n, err := s.srv.RestoreManyURLs(r.Context(), userID, data)
+	if errors.Is(err, srv.ErrForbidden) {
+		s.handleErrorOrStatus(w, err, fields, http.StatusForbidden)
+		return
+	}
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+
+	res, err := json.Marshal(model.RestoreURLsResponse{Restored: n})
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(res)
+	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
+}
+
+// handleInternalPurge gives trusted user access to physically remove urls which were deleted
+// more than days (query param) days ago.
+func (s *Server) handleInternalPurge(w http.ResponseWriter, r *http.Request) {
+	xRealIP := r.Header.Get("X-Real-IP")
+	fields := []zap.Field{
+		zap.String("request_id", middleware.GetReqID(r.Context())),
+		zap.String("request_ip", xRealIP),
+	}
+
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err == nil && days < 0 {
+		err = errors.New("days must not be negative")
+	}
+	if s.handleErrorOrStatus(w, err, fields, http.StatusBadRequest) {
+		return
+	}
+
+	n, err := s.srv.PurgeDeleted(r.Context(), xRealIP, time.Duration(days)*24*time.Hour)
+	switch {
+	case errors.Is(err, srv.ErrForbidden):
+		s.handleErrorOrStatus(w, err, fields, http.StatusForbidden)
+		return
+	case s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError):
+		return
+	}
+
+	data, err := json.Marshal(model.PurgeURLsResponse{Purged: n})
+	if s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
+}
+
 // handleInternalStats give trusted user access to specific stats about data records.
 func (s *Server) handleInternalStats(w http.ResponseWriter, r *http.Request) {
 	xRealIP := r.Header.Get("X-Real-IP")
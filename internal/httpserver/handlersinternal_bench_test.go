@@ -133,7 +133,7 @@ func BenchmarkServer_handleURLPostJSON(b *testing.B) {
 				b.StopTimer()
 				// prepare test recorder and test request
 				w := httptest.NewRecorder()
-				r := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url": "ya.ru"}`))
+				r := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url": "https://ya.ru"}`))
 				b.StartTimer()
 
 				s.handleURLCreateJSON(w, r)
@@ -151,9 +151,9 @@ func BenchmarkServer_handleURLPostJSON(b *testing.B) {
 func BenchmarkServer_handleURLBatchCreate(b *testing.B) {
 	data := `
 	[
-		{"original_url": "ya.ru/a", "correlation_id": "a"},
-		{"original_url": "ya.ru/b", "correlation_id": "b"},
-		{"original_url": "ya.ru/c", "correlation_id": "c"}
+		{"original_url": "https://ya.ru/a", "correlation_id": "a"},
+		{"original_url": "https://ya.ru/b", "correlation_id": "b"},
+		{"original_url": "https://ya.ru/c", "correlation_id": "c"}
 	]`
 	tt := map[string]error{
 		"no error": nil,
@@ -10,9 +10,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -120,7 +124,8 @@ func TestServer_HandleURLGetAndCreate(t *testing.T) {
 
 	storage := inmemory.New()
 	l, _ := zap.NewProduction()
-	srvc := srv.New(l, storage)
+	srvc, err := srv.New(l, storage)
+	require.NoError(t, err)
 	defer require.NoError(t, srvc.Close())
 	s := New(srvc, l)
 	s.config = &config.Config{
@@ -196,7 +201,8 @@ func TestServer_HandleURLGet(t *testing.T) {
 
 	storage := inmemory.New()
 	l, _ := zap.NewProduction()
-	srvc := srv.New(l, storage)
+	srvc, err := srv.New(l, storage)
+	require.NoError(t, err)
 	defer require.NoError(t, srvc.Close())
 	s := New(srvc, l)
 	s.config = &config.Config{
@@ -587,8 +593,8 @@ func TestServer_handleURLGetAllByUser_Positive(t *testing.T) {
 
 			storage.
 				EXPECT().
-				GetAllUserURLs(gomock.Any(), u).
-				Return(urls, nil).
+				ListUserURLs(gomock.Any(), u, gomock.Any()).
+				Return(&store.Page{URLs: urls}, nil).
 				AnyTimes()
 
 			w := httptest.NewRecorder()
@@ -602,8 +608,9 @@ func TestServer_handleURLGetAllByUser_Positive(t *testing.T) {
 			var responseURLs []*model.AllUserURLsResponse
 			for _, u := range urls {
 				resp := &model.AllUserURLsResponse{
-					ShortURL:    fmt.Sprintf("%s/%s", server.config.BaseURL, u.ID),
-					OriginalURL: u.BaseURL,
+					ShortURL:     fmt.Sprintf("%s/%s", server.config.BaseURL, u.ID),
+					OriginalURL:  u.BaseURL,
+					RedirectCode: model.DefaultRedirectCode,
 				}
 				responseURLs = append(responseURLs, resp)
 			}
@@ -647,6 +654,21 @@ func TestServer_handleURLGet(t *testing.T) {
 			},
 			code: http.StatusTemporaryRedirect,
 		},
+		{
+			name: "permanent redirect",
+			args: args{
+				id:  "a",
+				url: "https://ya.ru",
+			},
+			mock: mock{
+				u: &model.URL{
+					BaseURL:      "https://ya.ru",
+					ID:           "a",
+					RedirectCode: http.StatusPermanentRedirect,
+				},
+			},
+			code: http.StatusPermanentRedirect,
+		},
 		{
 			name: "is deleted",
 			args: args{
@@ -659,6 +681,18 @@ func TestServer_handleURLGet(t *testing.T) {
 			},
 			code: http.StatusGone,
 		},
+		{
+			name: "is expired",
+			args: args{
+				id:  "a",
+				url: "https://ya.ru",
+			},
+			mock: mock{
+				u:     nil,
+				error: store.ErrExpired,
+			},
+			code: http.StatusGone,
+		},
 		{
 			name: "internal error",
 			args: args{
@@ -707,10 +741,845 @@ func TestServer_handleURLGet(t *testing.T) {
 			defer require.NoError(t, r.Body.Close())
 
 			assert.Equal(t, tc.code, res.StatusCode)
-			if tc.code != http.StatusTemporaryRedirect {
+			if tc.mock.u == nil {
 				return
 			}
 			assert.Contains(t, tc.args.url, res.Header.Get("location"))
 		})
 	}
 }
+
+func TestServer_handleURLCreateJSON_Alias(t *testing.T) {
+	tt := []struct {
+		name string
+		data string
+		code int
+	}{
+		{
+			name: "positive case",
+			data: `{"url": "https://example.org", "alias": "spring-sale"}`,
+			code: http.StatusCreated,
+		},
+		{
+			name: "alias is taken",
+			data: `{"url": "https://example.com", "alias": "spring-sale"}`,
+			code: http.StatusConflict,
+		},
+		{
+			name: "reserved alias",
+			data: `{"url": "https://example.com", "alias": "api"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad alias",
+			data: `{"url": "https://example.com", "alias": "spring sale"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad tag",
+			data: `{"url": "https://example.com", "title": "Example", "tags": ["spring sale"]}`,
+			code: http.StatusBadRequest,
+		},
+	}
+
+	s, td := TestServer(t, inmemory.New())
+	defer require.NoError(t, td())
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(tc.data))
+
+			s.handleURLCreateJSON(w, r)
+			res := w.Result()
+			defer assert.NoError(t, res.Body.Close())
+
+			assert.Equal(t, tc.code, res.StatusCode)
+			if tc.code != http.StatusCreated {
+				return
+			}
+			assert.JSONEq(t, fmt.Sprintf(`{"result": "%s/spring-sale"}`, s.config.BaseURL), w.Body.String())
+		})
+	}
+}
+
+func TestServer_handleGetLinkStats(t *testing.T) {
+	stats := &model.LinkStats{
+		ID:    "a",
+		User:  "marlo",
+		Total: 2,
+		Daily: []*model.DailyClicks{{Date: "2023-01-02", Count: 2}},
+	}
+	tt := []struct {
+		name  string
+		user  string
+		stats *model.LinkStats
+		err   error
+		code  int
+	}{
+		{
+			name:  "positive case",
+			user:  "marlo",
+			stats: stats,
+			code:  http.StatusOK,
+		},
+		{
+			name:  "not owner",
+			user:  "another",
+			stats: stats,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:  "no user",
+			stats: &model.LinkStats{ID: "a"},
+			code:  http.StatusForbidden,
+		},
+		{
+			name: "not found",
+			user: "marlo",
+			err:  store.ErrNotFound,
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			user: "marlo",
+			err:  errUnknownErr,
+			code: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mock_store.NewMockStore(ctrl)
+			storage.
+				EXPECT().
+				GetLinkStats(gomock.Any(), "a").
+				Return(tc.stats, tc.err).
+				AnyTimes()
+			s, td := TestServer(t, storage)
+			defer require.NoError(t, td())
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls/a/stats", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "a")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(context.WithValue(ctx, middleware.UserCtxKey{}, tc.user))
+
+			s.handleGetLinkStats(w, r)
+			res := w.Result()
+			defer assert.NoError(t, res.Body.Close())
+
+			assert.Equal(t, tc.code, res.StatusCode)
+			if tc.code != http.StatusOK {
+				return
+			}
+			assert.JSONEq(t, `{"id": "a", "total": 2, "daily": [{"date": "2023-01-02", "count": 2}]}`, w.Body.String())
+		})
+	}
+}
+
+func TestServer_handleURLBulkRestore(t *testing.T) {
+	tt := []struct {
+		name     string
+		user     string
+		body     string
+		restored int64
+		err      error
+		code     int
+	}{
+		{
+			name:     "positive case",
+			user:     "marlo",
+			body:     `["a", "b"]`,
+			restored: 2,
+			code:     http.StatusOK,
+		},
+		{
+			name: "bad body",
+			user: "marlo",
+			body: `{"id": "a"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			user: "marlo",
+			body: `["a", "b"]`,
+			err:  errUnknownErr,
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "no user",
+			body: `["a", "b"]`,
+			code: http.StatusForbidden,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mock_store.NewMockStore(ctrl)
+			storage.
+				EXPECT().
+				URLsBulkRestore(gomock.Any(), []string{"a", "b"}, "marlo").
+				Return(tc.restored, tc.err).
+				AnyTimes()
+			s, td := TestServer(t, storage)
+			defer func() {
+				require.NoError(t, td())
+			}()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/user/urls/restore", strings.NewReader(tc.body))
+			r = r.WithContext(context.WithValue(r.Context(), middleware.UserCtxKey{}, tc.user))
+
+			s.handleURLBulkRestore(w, r)
+			res := w.Result()
+			defer func() {
+				assert.NoError(t, res.Body.Close())
+			}()
+			require.Equal(t, tc.code, res.StatusCode)
+			if tc.code != http.StatusOK {
+				return
+			}
+			var resp model.RestoreURLsResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			assert.Equal(t, tc.restored, resp.Restored)
+		})
+	}
+}
+
+func TestServer_handleInternalPurge(t *testing.T) {
+	tt := []struct {
+		name string
+		days string
+		ip   string
+		code int
+	}{
+		{name: "no days", ip: "127.0.0.1", code: http.StatusBadRequest},
+		{name: "negative days", days: "-1", ip: "127.0.0.1", code: http.StatusBadRequest},
+		{name: "not trusted", days: "30", ip: "", code: http.StatusForbidden},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mock_store.NewMockStore(ctrl)
+			s, td := TestServer(t, storage)
+			defer func() {
+				require.NoError(t, td())
+			}()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/internal/purge?days="+tc.days, nil)
+			r.Header.Set("X-Real-IP", tc.ip)
+
+			s.handleInternalPurge(w, r)
+			res := w.Result()
+			defer func() {
+				assert.NoError(t, res.Body.Close())
+			}()
+			assert.Equal(t, tc.code, res.StatusCode)
+		})
+	}
+}
+
+func TestServer_handleGetUserURLs_Pagination(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	for _, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, storage.Create(ctx, u))
+	}
+
+	get := func(query string) *http.Response {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/user/urls?"+query, nil)
+		r = r.WithContext(context.WithValue(r.Context(), middleware.UserCtxKey{}, "marlo"))
+		s.handleGetUserURLs(w, r)
+		return w.Result()
+	}
+	decode := func(res *http.Response) (urls []*model.AllUserURLsResponse) {
+		defer func() {
+			assert.NoError(t, res.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&urls))
+		return urls
+	}
+
+	res := get("limit=2&sort=original_url")
+	first := decode(res)
+	require.Len(t, first, 2)
+	assert.Equal(t, "https://example.com", first[0].OriginalURL)
+	assert.False(t, first[0].CreatedAt.IsZero())
+	assert.Equal(t, first[0].CreatedAt, first[0].UpdatedAt)
+	assert.Equal(t, "https://example.net", first[1].OriginalURL)
+	cursor := res.Header.Get(nextCursorHeader)
+	require.NotEmpty(t, cursor)
+
+	res = get("limit=2&sort=original_url&cursor=" + cursor)
+	second := decode(res)
+	require.Len(t, second, 1)
+	assert.Equal(t, "https://example.org", second[0].OriginalURL)
+	assert.Empty(t, res.Header.Get(nextCursorHeader))
+
+	filtered := decode(get("q=EXAMPLE.N"))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "https://example.net", filtered[0].OriginalURL)
+
+	tagged, err := model.NewURL("https://example.io", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, tagged.Apply(model.WithMetadata("Example", "", []string{"marketing"})))
+	require.NoError(t, storage.Create(ctx, tagged))
+	filtered = decode(get("tag=marketing"))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "https://example.io", filtered[0].OriginalURL)
+	assert.Equal(t, "Example", filtered[0].Title)
+	assert.Equal(t, []string{"marketing"}, filtered[0].Tags)
+
+	for _, query := range []string{
+		"limit=x",
+		"limit=-1",
+		"sort=id",
+		"created_from=yesterday",
+		"tag=bad%20tag",
+		"cursor=bad",
+		// cursor of list with other sort
+		"sort=-created_at&cursor=" + cursor,
+	} {
+		res = get(query)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+		assert.NoError(t, res.Body.Close())
+	}
+}
+
+func TestServer_handleURLUpdate(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	// urls are stored in canonical form as service stores them.
+	u, err := model.NewURL("https://example.org/", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, storage.Create(ctx, u))
+	other, err := model.NewURL("https://example.net/", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, storage.Create(ctx, other))
+
+	do := func(method, target, user, id string, body string) *http.Response {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+		r = r.WithContext(context.WithValue(ctx, middleware.UserCtxKey{}, user))
+		if method == http.MethodPatch {
+			s.handleURLUpdate(w, r)
+		} else {
+			s.handleGetURLHistory(w, r)
+		}
+		return w.Result()
+	}
+	history := func(user, id string) *http.Response {
+		return do(http.MethodGet, "/api/user/urls/"+id+"/history", user, id, "")
+	}
+	update := func(user, id, body string) *http.Response {
+		return do(http.MethodPatch, "/api/user/urls/"+id, user, id, body)
+	}
+
+	res := history("marlo", u.ID)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.NoError(t, res.Body.Close())
+
+	tt := []struct {
+		name string
+		user string
+		id   string
+		body string
+		code int
+	}{
+		{name: "bad body", user: "marlo", id: u.ID, body: `"https://example.com"`, code: http.StatusBadRequest},
+		{name: "bad url", user: "marlo", id: u.ID, body: `{"url": "https://example .com"}`, code: http.StatusBadRequest},
+		{name: "not owner", user: "another", id: u.ID, body: `{"url": "https://example.com"}`, code: http.StatusForbidden},
+		{name: "no user", id: u.ID, body: `{"url": "https://example.com"}`, code: http.StatusForbidden},
+		{name: "not found", user: "marlo", id: "unknown", body: `{"url": "https://example.com"}`, code: http.StatusNotFound},
+		{name: "already shortened", user: "marlo", id: u.ID, body: `{"url": "https://example.net"}`, code: http.StatusConflict},
+		{name: "bad redirect code", user: "marlo", id: u.ID, body: `{"url": "https://example.com", "redirect_code": 200}`, code: http.StatusBadRequest},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := update(tc.user, tc.id, tc.body)
+			assert.Equal(t, tc.code, res.StatusCode)
+			assert.NoError(t, res.Body.Close())
+		})
+	}
+
+	res = update("marlo", u.ID, `{"url": "https://example.com"}`)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var updated model.AllUserURLsResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	assert.NoError(t, res.Body.Close())
+	assert.Equal(t, "https://example.com", updated.OriginalURL)
+	assert.Equal(t, "https://example.com/", updated.CanonicalURL)
+	assert.True(t, strings.HasSuffix(updated.ShortURL, "/"+u.ID))
+
+	res = history("another", u.ID)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.NoError(t, res.Body.Close())
+
+	res = history("marlo", u.ID)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var destinations []*model.Destination
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&destinations))
+	assert.NoError(t, res.Body.Close())
+	require.Len(t, destinations, 1)
+	assert.Equal(t, "https://example.org/", destinations[0].OriginalURL)
+	assert.Equal(t, updated.UpdatedAt, destinations[0].ReplacedAt)
+
+	res = update("marlo", u.ID, `{"redirect_code": 301}`)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	assert.NoError(t, res.Body.Close())
+	assert.Equal(t, "https://example.com", updated.OriginalURL)
+	assert.Equal(t, http.StatusMovedPermanently, updated.RedirectCode)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/"+u.ID, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", u.ID)
+	s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "https://example.com/", w.Header().Get("Location"))
+
+	// new original url is canonicalized, so its other notation is duplicate too.
+	res = update("marlo", other.ID, `{"url": "HTTPS://EXAMPLE.COM:443"}`)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	assert.NoError(t, res.Body.Close())
+
+	res = update("marlo", u.ID, `{"url": "https://Example.com:443/a?b=1&a=2"}`)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	assert.NoError(t, res.Body.Close())
+	assert.Equal(t, "https://Example.com:443/a?b=1&a=2", updated.OriginalURL)
+	assert.Equal(t, "https://example.com/a?a=2&b=1", updated.CanonicalURL)
+	got, err := storage.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a?a=2&b=1", got.BaseURL)
+	assert.Equal(t, "https://Example.com:443/a?b=1&a=2", got.RawURL)
+}
+
+func TestServer_handleURLUnlock(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithPassword("secret"), model.WithRedirectCode(http.StatusPermanentRedirect)))
+	require.NoError(t, storage.Create(ctx, u))
+
+	request := func(method string, body io.Reader) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, "/"+u.ID, body)
+		if body != nil {
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", u.ID)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+		if method == http.MethodPost {
+			s.handleURLUnlock(w, r)
+		} else {
+			s.handleURLGet(w, r)
+		}
+		return w
+	}
+	unlock := func(password string) *httptest.ResponseRecorder {
+		return request(http.MethodPost, strings.NewReader(url.Values{"password": {password}}.Encode()))
+	}
+
+	w := request(http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+	assert.Contains(t, w.Body.String(), `<form method="post">`)
+
+	w = unlock("wrong")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Wrong password.")
+
+	w = unlock("secret")
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "https://example.org", w.Header().Get("Location"))
+
+	for i := 2; i < s.config.PasswordAttempts; i++ {
+		assert.Equal(t, http.StatusForbidden, unlock("wrong").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, unlock("secret").Code)
+}
+
+func TestServer_handleURLGet_MaxClicks(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithMaxClicks(1)))
+	require.NoError(t, storage.Create(ctx, u))
+
+	get := func() int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/"+u.ID, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", u.ID)
+		s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusTemporaryRedirect, get())
+	assert.Equal(t, http.StatusGone, get())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+	s.handleGetUserURLs(w, r.WithContext(context.WithValue(r.Context(), middleware.UserCtxKey{}, "marlo")))
+	require.Equal(t, http.StatusOK, w.Code)
+	var urls []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&urls))
+	require.Len(t, urls, 1)
+	assert.Equal(t, float64(1), urls[0]["max_clicks"])
+	assert.Equal(t, float64(0), urls[0]["clicks_left"])
+}
+
+func TestServer_handleURLGet_Rules(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.OptionsOf(&model.CreateURLRequest{Rules: []*model.Rule{
+		{Device: model.DeviceIOS, Destination: "https://apps.apple.com"},
+		{Languages: []string{"de"}, Destination: "https://example.org/de"},
+	}})...))
+	require.NoError(t, storage.Create(ctx, u))
+
+	tt := []struct {
+		name      string
+		userAgent string
+		language  string
+		location  string
+	}{
+		{name: "ios", userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X)", location: "https://apps.apple.com"},
+		{name: "german", userAgent: "Mozilla/5.0 (X11; Linux x86_64)", language: "de-DE,de;q=0.9", location: "https://example.org/de"},
+		{name: "other", userAgent: "Mozilla/5.0 (X11; Linux x86_64)", language: "en-US", location: "https://example.org"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/"+u.ID, nil)
+			r.Header.Set("User-Agent", tc.userAgent)
+			r.Header.Set("Accept-Language", tc.language)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", u.ID)
+			s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+
+			assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+			assert.Equal(t, tc.location, w.Header().Get("Location"))
+			assert.NotEmpty(t, w.Header().Get("Vary"))
+			assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestServer_handleURLGet_Variants(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithVariants([]*model.Variant{
+		{Name: "a", Destination: "https://example.org/a", Weight: 1},
+		{Name: "b", Destination: "https://example.org/b", Weight: 1},
+	})))
+	require.NoError(t, storage.Create(ctx, u))
+
+	get := func(cookie *http.Cookie) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/"+u.ID, nil)
+		if cookie != nil {
+			r.AddCookie(cookie)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", u.ID)
+		s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+		return w
+	}
+
+	w := get(nil)
+	require.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, variantCookie, cookies[0].Name)
+	assert.Equal(t, "/"+u.ID, cookies[0].Path)
+	assert.Equal(t, "https://example.org/"+cookies[0].Value, w.Header().Get("Location"))
+
+	for i := 0; i < 10; i++ {
+		w = get(&http.Cookie{Name: variantCookie, Value: "b"})
+		assert.Equal(t, "https://example.org/b", w.Header().Get("Location"))
+	}
+}
+
+func TestServer_handleURLGet_Passthrough(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	u, err := model.NewURL("https://example.org/landing?ref=short", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithPassthrough(), model.WithUTM(&model.UTM{Source: "short", Medium: "link"})))
+	require.NoError(t, storage.Create(ctx, u))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/"+u.ID+"?utm_source=twitter", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", u.ID)
+	s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "https://example.org/landing?ref=short&utm_medium=link&utm_source=twitter", w.Header().Get("Location"))
+}
+
+func TestServer_handleURLCreateJSON_Canonical(t *testing.T) {
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+
+	create := func(url string) (int, string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url": "`+url+`"}`))
+		s.handleURLCreateJSON(w, r)
+		var resp model.ResultResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return w.Code, resp.Result
+	}
+
+	code, first := create("HTTPS://Example.com:443/a?b=1&a=2")
+	require.Equal(t, http.StatusCreated, code)
+	code, second := create("https://example.com/a?a=2&b=1")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, first, second)
+
+	id := first[strings.LastIndex(first, "/")+1:]
+	u, err := storage.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a?a=2&b=1", u.BaseURL)
+	assert.Equal(t, "HTTPS://Example.com:443/a?b=1&a=2", u.Display())
+}
+
+func TestServer_handleURLCreate_InvalidURL(t *testing.T) {
+	s, td := TestServer(t, inmemory.New())
+	defer func() {
+		require.NoError(t, td())
+	}()
+
+	tt := []struct {
+		name string
+		url  string
+		err  error
+	}{
+		{name: "javascript", url: "javascript:alert(1)", err: model.ErrURLSchemeNotAllowed},
+		{name: "file", url: "file:///etc/passwd", err: model.ErrURLSchemeNotAllowed},
+		{name: "garbage", url: "garbage", err: model.ErrURLBadFormat},
+		{name: "no host", url: "https:///path", err: model.ErrURLNoHost},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.handleURLCreate(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.url)))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, tc.err.Error(), strings.TrimSpace(w.Body.String()))
+
+			w = httptest.NewRecorder()
+			s.handleURLCreateJSON(w, httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url": "`+tc.url+`"}`)))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, tc.err.Error(), strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestServer_DomainPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.txt")
+	require.NoError(t, os.WriteFile(path, []byte("block *.blocked.test\n"), 0o600))
+	orig := config.Get()
+	cfg := orig.Copy()
+	cfg.DomainPolicyFile = filepath.Join(t.TempDir(), "missing.txt")
+	config.Set(cfg)
+	defer config.Set(orig)
+	_, err := srv.New(zap.NewNop(), inmemory.New())
+	require.Error(t, err, "service must not be created without domain policy")
+
+	cfg = orig.Copy()
+	cfg.DomainPolicyFile = path
+	config.Set(cfg)
+
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+
+	const rule = `domain www.blocked.test is blocked by policy rule "block *.blocked.test (line 1)"`
+	w := httptest.NewRecorder()
+	s.handleURLCreate(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://www.blocked.test/")))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, rule, strings.TrimSpace(w.Body.String()))
+
+	w = httptest.NewRecorder()
+	body := `{"url": "https://example.org/", "variants": [{"destination": "https://www.blocked.test/", "weight": 1}]}`
+	s.handleURLCreateJSON(w, httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(body)))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, rule, strings.TrimSpace(w.Body.String()))
+
+	// url which was created before its domain was blocked is not opened.
+	u := &model.URL{ID: "blocked", BaseURL: "https://www.blocked.test/"}
+	require.NoError(t, storage.Create(context.Background(), u))
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/"+u.ID, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", u.ID)
+	s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, rule, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_SelfReference(t *testing.T) {
+	orig := config.Get()
+	cfg := orig.Copy()
+	cfg.AliasDomains = []string{"sho.rt"}
+	config.Set(cfg)
+	defer config.Set(orig)
+
+	ctx := context.Background()
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	require.NoError(t, storage.Create(ctx, &model.URL{ID: "final", BaseURL: "https://example.org/final"}))
+	require.NoError(t, storage.Create(ctx, &model.URL{ID: "chain", BaseURL: cfg.BaseURL + "/final"}))
+	require.NoError(t, storage.Create(ctx, &model.URL{ID: "protected", BaseURL: "https://example.org/", PasswordHash: "hash"}))
+
+	tt := []struct {
+		name string
+		url  string
+		want string
+		err  error
+	}{
+		// resolved url is duplicate of url which it points to.
+		{name: "base url", url: cfg.BaseURL + "/final", want: "final"},
+		{name: "alias domain", url: "https://SHO.RT/final", want: "final"},
+		{name: "chain", url: cfg.BaseURL + "/chain", want: "final"},
+		{name: "missing", url: "https://sho.rt/missing", err: srv.ErrSelfReference},
+		{name: "not short url", url: cfg.BaseURL + "/api/user/urls", err: srv.ErrSelfReference},
+		{name: "protected", url: cfg.BaseURL + "/protected", err: srv.ErrSelfReference},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.handleURLCreate(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.url)))
+			if tc.err != nil {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Equal(t, tc.err.Error(), strings.TrimSpace(w.Body.String()))
+				return
+			}
+			assert.Equal(t, http.StatusConflict, w.Code)
+			assert.Equal(t, cfg.BaseURL+"/"+tc.want, w.Body.String())
+		})
+	}
+
+	// loop which was created before loops were checked isn't followed.
+	require.NoError(t, storage.Create(ctx, &model.URL{ID: "tick", BaseURL: cfg.BaseURL + "/tock"}))
+	require.NoError(t, storage.Create(ctx, &model.URL{ID: "tock", BaseURL: "https://sho.rt/tick"}))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/tick", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "tick")
+	s.handleURLGet(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+	assert.Equal(t, http.StatusLoopDetected, w.Code)
+}
+
+func TestServer_handleURLCreateJSON_ProtectedDuplicate(t *testing.T) {
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	create := func(body string) (int, string) {
+		w := httptest.NewRecorder()
+		s.handleURLCreateJSON(w, httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(body)))
+		resp := new(model.ResultResponse)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		return w.Code, strings.TrimPrefix(resp.Result, s.config.BaseURL+"/")
+	}
+
+	code, plainID := create(`{"url": "https://example.org/doc"}`)
+	require.Equal(t, http.StatusCreated, code)
+
+	// url which is already shortened gets new protected link instead of existing unprotected one.
+	code, protectedID := create(`{"url": "https://example.org/doc", "password": "secret"}`)
+	require.Equal(t, http.StatusCreated, code)
+	assert.NotEqual(t, plainID, protectedID)
+	u, err := storage.GetByID(context.Background(), protectedID)
+	require.NoError(t, err)
+	assert.True(t, u.IsProtected())
+
+	code, id := create(`{"url": "https://example.org/doc"}`)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, plainID, id)
+}
+
+func TestServer_handleURLCreateJSON_LimitedDuplicate(t *testing.T) {
+	storage := inmemory.New()
+	s, td := TestServer(t, storage)
+	defer func() {
+		require.NoError(t, td())
+	}()
+	create := func(body string) (int, string) {
+		w := httptest.NewRecorder()
+		s.handleURLCreateJSON(w, httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(body)))
+		resp := new(model.ResultResponse)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		return w.Code, strings.TrimPrefix(resp.Result, s.config.BaseURL+"/")
+	}
+
+	code, plainID := create(`{"url": "https://example.org/invite"}`)
+	require.Equal(t, http.StatusCreated, code)
+
+	// every one-time link is own link, even if url is already shortened.
+	for i := 0; i < 2; i++ {
+		code, id := create(`{"url": "https://example.org/invite", "max_clicks": 1}`)
+		require.Equal(t, http.StatusCreated, code)
+		assert.NotEqual(t, plainID, id)
+		u, err := storage.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), u.ClicksLeft)
+	}
+}
@@ -1,13 +1,21 @@
 package httpserver
 
 import (
+	"errors"
 	"fmt"
+	"html/template"
 	"net/http"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/vlad-marlo/shortener/internal/domainpolicy"
 	"github.com/vlad-marlo/shortener/internal/httpserver/middleware"
+	srv "github.com/vlad-marlo/shortener/internal/service"
+	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
 // handleErrorOrStatus return true and handle error if err is not nil.
@@ -32,6 +40,150 @@ func (s *Server) handleErrorOrStatus(w http.ResponseWriter, err error, fields []
 	return err != nil
 }
 
+// handleURLValidationError handles error of original url validation: it responds with bad request status and
+// message of error, so client knows which check url failed. Url which is blocked by domain policy is
+// responded with forbidden status and rule which blocked it. It returns true if error is handled.
+func (s *Server) handleURLValidationError(w http.ResponseWriter, err error, fields []zap.Field) bool {
+	if s.handleDomainBlockedError(w, err, fields) {
+		return true
+	}
+	urlErr := model.URLError(err)
+	switch {
+	case urlErr != nil:
+	case errors.Is(err, srv.ErrSelfReference):
+		urlErr = srv.ErrSelfReference
+	case errors.Is(err, srv.ErrRedirectLoop):
+		urlErr = srv.ErrRedirectLoop
+	default:
+		return false
+	}
+	s.logger.Debug(fmt.Sprintf("%v", err), fields...)
+	http.Error(w, urlErr.Error(), http.StatusBadRequest)
+	return true
+}
+
+// handleDomainBlockedError responds with forbidden status and message of error if url is blocked by domain
+// policy. It returns true if error is handled.
+func (s *Server) handleDomainBlockedError(w http.ResponseWriter, err error, fields []zap.Field) bool {
+	var blocked *domainpolicy.BlockedError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+	s.logger.Debug(fmt.Sprintf("%v", err), fields...)
+	http.Error(w, blocked.Error(), http.StatusForbidden)
+	return true
+}
+
+// handleURLAccessError handles errors of access to url of user: missing url, deleted or expired url and url
+// of other user. It returns true if error is handled.
+func (s *Server) handleURLAccessError(w http.ResponseWriter, err error, fields []zap.Field) bool {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return s.handleErrorOrStatus(w, err, fields, http.StatusNotFound)
+	case errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired):
+		return s.handleErrorOrStatus(w, err, fields, http.StatusGone)
+	case errors.Is(err, srv.ErrForbidden):
+		return s.handleErrorOrStatus(w, err, fields, http.StatusForbidden)
+	}
+	return false
+}
+
+// handleOpenURLError handles errors of url opening for redirect. It returns true if error is handled.
+func (s *Server) handleOpenURLError(w http.ResponseWriter, err error, fields []zap.Field) bool {
+	switch {
+	case errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired), errors.Is(err, store.ErrExhausted):
+		w.WriteHeader(http.StatusGone)
+		return true
+	case errors.Is(err, store.ErrNotFound):
+		return s.handleErrorOrStatus(w, errors.New("where is no url with that id"), fields, http.StatusNotFound)
+	case errors.Is(err, srv.ErrRedirectLoop):
+		return s.handleErrorOrStatus(w, err, fields, http.StatusLoopDetected)
+	case s.handleDomainBlockedError(w, err, fields):
+		return true
+	}
+	return s.handleErrorOrStatus(w, err, fields, http.StatusInternalServerError)
+}
+
+// variantCookie is name of cookie with name of split variant which was chosen for visitor. Cookie is set
+// for path of url, so every url has own variant.
+const variantCookie = "variant"
+
+// variantCookieMaxAge ...
+const variantCookieMaxAge = 30 * 24 * time.Hour
+
+// redirect records click on url and redirects user to target of url with provided http status. Split
+// variant of visitor is kept in cookie, so repeat clicks land on same variant. Query of request is
+// forwarded to target if passthrough of url is enabled.
+func (s *Server) redirect(w http.ResponseWriter, r *http.Request, url *model.URL, status int) {
+	now := time.Now().UTC()
+	visitor := &model.Visitor{
+		UserAgent:      r.UserAgent(),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		Time:           now,
+	}
+	if c, err := r.Cookie(variantCookie); err == nil {
+		visitor.Variant = c.Value
+	}
+	target, variant := url.Target(visitor)
+
+	click := &model.Click{
+		URLID:     url.ID,
+		Time:      now,
+		Referer:   r.Referer(),
+		UserAgent: r.UserAgent(),
+		IP:        r.Header.Get("X-Real-IP"),
+	}
+	if variant != nil {
+		click.Variant = variant.Name
+		http.SetCookie(w, &http.Cookie{
+			Name:     variantCookie,
+			Value:    variant.Name,
+			Path:     "/" + url.ID,
+			MaxAge:   int(variantCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	s.srv.RecordClick(click)
+
+	// location depends on visitor, so caches must not reuse it for other visitors.
+	switch {
+	case len(url.Variants) != 0:
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Set("Vary", "User-Agent, Accept-Language, Cookie")
+	case len(url.Rules) != 0:
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Set("Vary", "User-Agent, Accept-Language")
+	}
+	w.Header().Set("Location", url.Location(target, r.URL.RawQuery))
+	w.WriteHeader(status)
+}
+
+// passwordForm is page with form which sends password of protected url to same path.
+var passwordForm = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Protected link</title></head>
+<body>
+<p>This link is protected with password.</p>
+{{if .}}<p>{{.}}</p>{{end}}
+<form method="post">
+<input type="password" name="password" autofocus required>
+<button type="submit">Open</button>
+</form>
+</body>
+</html>
+`))
+
+// writePasswordForm writes password form with message to response.
+func (s *Server) writePasswordForm(w http.ResponseWriter, status int, message string, fields []zap.Field) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := passwordForm.Execute(w, message); err != nil {
+		s.logger.Error(fmt.Sprintf("write password form: %v", err), fields...)
+	}
+}
+
 // getUserFromRequest ...
 func getUserFromRequest(r *http.Request) string {
 	user := middleware.GetUserFromCtx(r.Context())
@@ -40,3 +192,19 @@ func getUserFromRequest(r *http.Request) string {
 	}
 	return user.(string)
 }
+
+// nextCursorHeader is header with cursor of next page of list.
+const nextCursorHeader = "X-Next-Cursor"
+
+// listOptionsFromQuery parses list options from query params of request.
+func listOptionsFromQuery(r *http.Request) (store.ListOptions, error) {
+	q := r.URL.Query()
+	var limit int
+	if l := q.Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			return store.ListOptions{}, fmt.Errorf("%w: limit must be integer", store.ErrBadListOptions)
+		}
+	}
+	return store.NewListOptions(limit, q.Get("cursor"), q.Get("q"), q.Get("created_from"), q.Get("created_to"), q.Get("tag"), q.Get("sort"))
+}
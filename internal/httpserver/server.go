@@ -13,6 +13,7 @@ import (
 
 	"github.com/vlad-marlo/shortener/internal/config"
 	"github.com/vlad-marlo/shortener/internal/httpserver/middleware"
+	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
@@ -24,13 +25,19 @@ var (
 
 type service interface {
 	Ping(ctx context.Context) error
-	CreateURL(ctx context.Context, user, url string) (*model.URL, error)
+	CreateURL(ctx context.Context, user, url string, opts ...model.URLOption) (*model.URL, error)
 	DeleteManyURLs(user string, urls []string)
-	GetAllURLsByUser(ctx context.Context, user string) ([]*model.AllUserURLsResponse, error)
-	NewURL(url, user string, correlationID ...string) (*model.URL, error)
+	ListURLsByUser(ctx context.Context, user string, opts store.ListOptions) ([]*model.AllUserURLsResponse, string, error)
+	NewURL(ctx context.Context, url, user string, correlationID ...string) (*model.URL, error)
 	CreateManyURLs(ctx context.Context, user string, urls []model.URLer) ([]*model.BatchCreateURLsResponse, error)
-	GetByID(ctx context.Context, id string) (*model.URL, error)
+	OpenURL(ctx context.Context, id, password string) (*model.URL, error)
 	GetInternalStats(ctx context.Context, ip string) (*model.InternalStat, error)
+	RecordClick(c *model.Click)
+	GetLinkStats(ctx context.Context, user, id string) (*model.LinkStats, error)
+	RestoreManyURLs(ctx context.Context, user string, ids []string) (int64, error)
+	UpdateURL(ctx context.Context, user, id, url string, opts ...model.URLOption) (*model.AllUserURLsResponse, error)
+	GetHistory(ctx context.Context, user, id string) ([]*model.Destination, error)
+	PurgeDeleted(ctx context.Context, ip string, olderThan time.Duration) (int64, error)
 }
 
 // Server ...
@@ -103,6 +110,7 @@ func (s *Server) configureRoutes() {
 
 	s.Post("/", s.handleURLCreate)
 	s.Get("/{id}", s.handleURLGet)
+	s.Post("/{id}", s.handleURLUnlock)
 
 	s.Get("/ping", s.handlePingStore)
 
@@ -113,7 +121,13 @@ func (s *Server) configureRoutes() {
 		r.Route("/user/urls", func(r chi.Router) {
 			r.Get("/", s.handleGetUserURLs)
 			r.Delete("/", s.handleURLBulkDelete)
+			r.Post("/restore", s.handleURLBulkRestore)
+			r.Patch("/{id}", s.handleURLUpdate)
+			r.Get("/{id}/history", s.handleGetURLHistory)
+			r.Get("/{id}/stats", s.handleGetLinkStats)
 		})
+
+		r.Post("/internal/purge", s.handleInternalPurge)
 	})
 }
 
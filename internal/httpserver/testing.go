@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/golang/mock/gomock"
 	"go.uber.org/zap"
 
 	"github.com/vlad-marlo/shortener/internal/config"
@@ -44,10 +45,15 @@ func TestServer(t TestI, storage store.Store) (*Server, func() error) {
 	t.Helper()
 	if s, ok := storage.(*mock_store.MockStore); ok {
 		s.EXPECT().Close().Return(nil).AnyTimes()
+		s.EXPECT().SaveClicks(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	}
+	service, err := srv.New(l, storage)
+	if err != nil {
+		t.Fatalf("init service: %v", err)
 	}
 	server := &Server{
 		logger: l,
-		srv:    srv.New(l, storage),
+		srv:    service,
 		config: c,
 	}
 	closer, ok := server.srv.(interface {
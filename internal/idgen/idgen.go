@@ -0,0 +1,227 @@
+// Package idgen contains strategies of short ids generation.
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	mathrand "math/rand"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// const ...
+const (
+	// Alphabet is base62 alphabet which is used by all generators.
+	Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// RandomStrategy generates random base62 ids of configured length.
+	RandomStrategy = "random"
+	// SequentialStrategy encodes counter of store in base62.
+	SequentialStrategy = "sequential"
+	// ObfuscatedStrategy encodes counter of store in base62 so consecutive ids don't look alike.
+	ObfuscatedStrategy = "obfuscated"
+
+	// DefaultLength ...
+	DefaultLength = 8
+	// MaxLength ...
+	MaxLength = 64
+)
+
+// vars ...
+var (
+	// ErrUnknownStrategy ...
+	ErrUnknownStrategy = errors.New("unknown id generation strategy")
+	// ErrBadLength ...
+	ErrBadLength = errors.New("id length must be from 1 to 64")
+	// ErrNoCounter ...
+	ErrNoCounter = errors.New("counter must be provided for counter based strategy")
+	// ErrCounterOverflow ...
+	ErrCounterOverflow = errors.New("counter is out of range of generator")
+)
+
+// Generator generates short ids of urls.
+type Generator interface {
+	GenerateID(ctx context.Context) (string, error)
+}
+
+// Counter returns next value of monotonic counter. It is implemented by stores.
+type Counter interface {
+	NextSequence(ctx context.Context) (uint64, error)
+}
+
+// New returns generator of provided strategy. Length is used only by random strategy and salt only by
+// obfuscated one.
+func New(strategy string, length int, salt string, counter Counter) (Generator, error) {
+	switch strategy {
+	case RandomStrategy, "":
+		return NewRandom(length)
+	case SequentialStrategy:
+		return NewSequential(counter)
+	case ObfuscatedStrategy:
+		return NewObfuscated(counter, salt)
+	default:
+		return nil, fmt.Errorf("%q: %w", strategy, ErrUnknownStrategy)
+	}
+}
+
+// encode returns n in base of alphabet.
+func encode(n uint64, alphabet string) string {
+	base := uint64(len(alphabet))
+	if n == 0 {
+		return alphabet[:1]
+	}
+	var buf [16]byte
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = alphabet[n%base]
+		n /= base
+	}
+	return string(buf[i:])
+}
+
+// unreserved calls next until it returns id which isn't reserved by paths of server. Counter based
+// generators skip values with reserved ids, so they are never taken.
+func unreserved(ctx context.Context, next func(ctx context.Context) (string, error)) (string, error) {
+	for {
+		id, err := next(ctx)
+		if err != nil || !model.IsReservedID(id) {
+			return id, err
+		}
+	}
+}
+
+// Random generates ids from random base62 chars.
+type Random struct {
+	length int
+}
+
+// NewRandom ...
+func NewRandom(length int) (*Random, error) {
+	if length == 0 {
+		length = DefaultLength
+	}
+	if length < 1 || length > MaxLength {
+		return nil, ErrBadLength
+	}
+	return &Random{length: length}, nil
+}
+
+// GenerateID ...
+func (r *Random) GenerateID(ctx context.Context) (string, error) {
+	return unreserved(ctx, r.generate)
+}
+
+// generate ...
+func (r *Random) generate(_ context.Context) (string, error) {
+	b := make([]byte, r.length)
+	max := big.NewInt(int64(len(Alphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = Alphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// Sequential generates ids from counter of store, so ids are as short as possible.
+type Sequential struct {
+	counter Counter
+}
+
+// NewSequential ...
+func NewSequential(counter Counter) (*Sequential, error) {
+	if counter == nil {
+		return nil, ErrNoCounter
+	}
+	return &Sequential{counter: counter}, nil
+}
+
+// GenerateID ...
+func (s *Sequential) GenerateID(ctx context.Context) (string, error) {
+	return unreserved(ctx, s.generate)
+}
+
+// generate ...
+func (s *Sequential) generate(ctx context.Context) (string, error) {
+	n, err := s.counter.NextSequence(ctx)
+	if err != nil {
+		return "", fmt.Errorf("next sequence: %w", err)
+	}
+	return encode(n, Alphabet), nil
+}
+
+// const ...
+const (
+	// obfuscatedBits is size of domain of obfuscated counter.
+	obfuscatedBits = 40
+	// obfuscatedMask ...
+	obfuscatedMask = 1<<obfuscatedBits - 1
+	// obfuscatedMultiplier is odd, so multiplication by it modulo 2^obfuscatedBits is bijection.
+	obfuscatedMultiplier = 0x9E3779B97F4A7C15 & obfuscatedMask
+	// obfuscatedLength is count of base62 chars which is enough to encode any value of domain.
+	obfuscatedLength = 7
+)
+
+// Obfuscated generates ids from counter of store like hashids or sqids do: counter is mixed with
+// bijective function and encoded with alphabet which is shuffled with salt. Ids are unique while
+// counter is unique, but neighbour ids look unrelated and can't be easily enumerated.
+type Obfuscated struct {
+	counter  Counter
+	alphabet string
+	key      uint64
+}
+
+// NewObfuscated ...
+func NewObfuscated(counter Counter, salt string) (*Obfuscated, error) {
+	if counter == nil {
+		return nil, ErrNoCounter
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(salt))
+	seed := h.Sum64()
+
+	alphabet := []byte(Alphabet)
+	rnd := mathrand.New(mathrand.NewSource(int64(seed)))
+	rnd.Shuffle(len(alphabet), func(i, j int) {
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	})
+	return &Obfuscated{
+		counter:  counter,
+		alphabet: string(alphabet),
+		key:      seed & obfuscatedMask,
+	}, nil
+}
+
+// obfuscate is bijection of [0, 2^obfuscatedBits).
+func (o *Obfuscated) obfuscate(n uint64) uint64 {
+	n = ((n ^ o.key) * obfuscatedMultiplier) & obfuscatedMask
+	return n ^ n>>(obfuscatedBits/2)
+}
+
+// GenerateID ...
+func (o *Obfuscated) GenerateID(ctx context.Context) (string, error) {
+	return unreserved(ctx, o.generate)
+}
+
+// generate ...
+func (o *Obfuscated) generate(ctx context.Context) (string, error) {
+	n, err := o.counter.NextSequence(ctx)
+	if err != nil {
+		return "", fmt.Errorf("next sequence: %w", err)
+	}
+	if n > obfuscatedMask {
+		return "", ErrCounterOverflow
+	}
+	id := encode(o.obfuscate(n), o.alphabet)
+	for len(id) < obfuscatedLength {
+		id = o.alphabet[:1] + id
+	}
+	return id, nil
+}
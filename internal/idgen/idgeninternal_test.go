@@ -0,0 +1,118 @@
+package idgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counter is in-memory Counter for tests.
+type counter uint64
+
+// NextSequence ...
+func (c *counter) NextSequence(_ context.Context) (uint64, error) {
+	*c++
+	return uint64(*c), nil
+}
+
+func TestNew(t *testing.T) {
+	tt := []struct {
+		name     string
+		strategy string
+		length   int
+		counter  Counter
+		want     Generator
+		wantErr  error
+	}{
+		{name: "default", want: &Random{}},
+		{name: "random", strategy: RandomStrategy, length: 4, want: &Random{}},
+		{name: "random with bad length", strategy: RandomStrategy, length: MaxLength + 1, wantErr: ErrBadLength},
+		{name: "sequential", strategy: SequentialStrategy, counter: new(counter), want: &Sequential{}},
+		{name: "sequential without counter", strategy: SequentialStrategy, wantErr: ErrNoCounter},
+		{name: "obfuscated", strategy: ObfuscatedStrategy, counter: new(counter), want: &Obfuscated{}},
+		{name: "obfuscated without counter", strategy: ObfuscatedStrategy, wantErr: ErrNoCounter},
+		{name: "unknown", strategy: "uuid", wantErr: ErrUnknownStrategy},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			gen, err := New(tc.strategy, tc.length, "", tc.counter)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, gen)
+		})
+	}
+}
+
+func TestRandom_GenerateID(t *testing.T) {
+	for _, length := range []int{0, 1, 8, MaxLength} {
+		gen, err := NewRandom(length)
+		require.NoError(t, err)
+		id, err := gen.GenerateID(context.Background())
+		require.NoError(t, err)
+		if length == 0 {
+			length = DefaultLength
+		}
+		assert.Len(t, id, length)
+		for _, r := range id {
+			assert.True(t, strings.ContainsRune(Alphabet, r))
+		}
+	}
+}
+
+func TestSequential_GenerateID(t *testing.T) {
+	c := counter(60)
+	gen, err := NewSequential(&c)
+	require.NoError(t, err)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := gen.GenerateID(context.Background())
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"z", "10", "11"}, ids)
+
+	// 141590 is "api" which is reserved.
+	c = 141589
+	id, err := gen.GenerateID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "apj", id)
+}
+
+func TestObfuscated_GenerateID(t *testing.T) {
+	c := new(counter)
+	gen, err := NewObfuscated(c, "salt")
+	require.NoError(t, err)
+
+	ids := make(map[string]struct{})
+	for i := 0; i < 10000; i++ {
+		id, err := gen.GenerateID(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, id, obfuscatedLength)
+		ids[id] = struct{}{}
+	}
+	assert.Len(t, ids, 10000, "ids must be unique")
+
+	// same salt produces same ids, other salt produces other ones
+	same, err := NewObfuscated(new(counter), "salt")
+	require.NoError(t, err)
+	other, err := NewObfuscated(new(counter), "pepper")
+	require.NoError(t, err)
+	id1, err := same.GenerateID(context.Background())
+	require.NoError(t, err)
+	id2, err := other.GenerateID(context.Background())
+	require.NoError(t, err)
+	_, ok := ids[id1]
+	assert.True(t, ok)
+	assert.NotEqual(t, id1, id2)
+
+	*c = obfuscatedMask
+	_, err = gen.GenerateID(context.Background())
+	assert.ErrorIs(t, err, ErrCounterOverflow)
+}
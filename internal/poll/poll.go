@@ -1,11 +1,25 @@
 package poll
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// const ...
+const (
+	// clicksQueueSize is max count of clicks which are waiting for saving.
+	clicksQueueSize = 1024
+	// clicksBatchSize is count of clicks after which they are saved without waiting for flush interval.
+	clicksBatchSize = 100
+	// clicksFlushInterval ...
+	clicksFlushInterval = time.Second
 )
 
 // types
@@ -14,8 +28,10 @@ type (
 	Poll struct {
 		store  store.Store
 		input  chan *task
+		clicks chan *model.Click
 		logger *zap.Logger
 		stop   chan struct{}
+		wg     sync.WaitGroup
 	}
 	// task ...
 	task struct {
@@ -29,10 +45,13 @@ func New(store store.Store, logger *zap.Logger) *Poll {
 	p := &Poll{
 		store:  store,
 		input:  make(chan *task, 10),
+		clicks: make(chan *model.Click, clicksQueueSize),
 		stop:   make(chan struct{}),
 		logger: logger,
 	}
 	go p.startPolling()
+	p.wg.Add(1)
+	go p.recordClicks()
 	return p
 }
 
@@ -49,6 +68,91 @@ func (p *Poll) DeleteURLs(urls []string, user string) {
 	}
 }
 
+// RecordClick pushes click to queue without blocking. If queue is full click will be dropped.
+func (p *Poll) RecordClick(c *model.Click) {
+	select {
+	case p.clicks <- c:
+	default:
+		p.logger.Warn("clicks queue is full: dropping click", zap.String("id", c.URLID))
+	}
+}
+
+// StartSweeping starts background sweeping of expired urls with provided interval.
+func (p *Poll) StartSweeping(interval time.Duration) {
+	if interval <= 0 || p.store == nil {
+		return
+	}
+	// sweeping is waited by Close, so store isn't closed while expired urls are swept.
+	p.wg.Add(1)
+	go p.sweep(interval)
+}
+
+// sweep ...
+func (p *Poll) sweep(interval time.Duration) {
+	defer p.wg.Done()
+	p.logger.Info("starting sweeping of expired urls", zap.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			n, err := p.store.SweepExpired(ctx)
+			cancel()
+			if err != nil {
+				p.logger.Warn(fmt.Sprintf("poll: sweep: %v", err))
+				continue
+			}
+			p.logger.Debug("successfully swept expired urls", zap.Int64("count", n))
+		}
+	}
+}
+
+// recordClicks collects clicks from queue and saves them to store by batches.
+func (p *Poll) recordClicks() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(clicksFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*model.Click, 0, clicksBatchSize)
+	flush := func() {
+		if len(batch) == 0 || p.store == nil {
+			batch = batch[:0]
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), clicksFlushInterval)
+		defer cancel()
+		if err := p.store.SaveClicks(ctx, batch); err != nil {
+			p.logger.Warn(fmt.Sprintf("poll: save clicks: %v", err), zap.Int("count", len(batch)))
+		}
+		batch = make([]*model.Click, 0, clicksBatchSize)
+	}
+
+	for {
+		select {
+		case <-p.stop:
+			for {
+				select {
+				case c := <-p.clicks:
+					batch = append(batch, c)
+				default:
+					flush()
+					return
+				}
+			}
+		case c := <-p.clicks:
+			batch = append(batch, c)
+			if len(batch) >= clicksBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
 // startPolling ...
 func (p *Poll) startPolling() {
 	p.logger.Info("starting poller polling")
@@ -75,8 +179,9 @@ func (p *Poll) startPolling() {
 	}
 }
 
-// Close ...
+// Close stops poller and waits until all queued clicks are saved and running sweep is finished.
 func (p *Poll) Close() {
 	p.logger.Info("close poller queue")
 	close(p.stop)
+	p.wg.Wait()
 }
@@ -8,4 +8,15 @@ import (
 var (
 	// ErrForbidden ...
 	ErrForbidden = errors.New("forbidden")
+	// ErrPasswordRequired ...
+	ErrPasswordRequired = errors.New("url is protected with password")
+	// ErrWrongPassword ...
+	ErrWrongPassword = errors.New("wrong password")
+	// ErrTooManyAttempts ...
+	ErrTooManyAttempts = errors.New("too many password attempts")
+	// ErrSelfReference is returned when original url points to service, but it can't be resolved to original
+	// url of short url.
+	ErrSelfReference = errors.New("url points to this service and can't be resolved to original url")
+	// ErrRedirectLoop is returned when original url leads back to short url through other short urls.
+	ErrRedirectLoop = errors.New("url leads to redirect loop")
 )
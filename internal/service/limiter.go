@@ -0,0 +1,57 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// attemptLimiter limits count of attempts by key in fixed time window.
+type attemptLimiter struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	attempts map[string]*attempts
+}
+
+// attempts is count of attempts in window which is started at start.
+type attempts struct {
+	start time.Time
+	count int
+}
+
+// newAttemptLimiter ...
+func newAttemptLimiter(limit int, window time.Duration) *attemptLimiter {
+	return &attemptLimiter{
+		limit:    limit,
+		window:   window,
+		attempts: make(map[string]*attempts),
+	}
+}
+
+// Allow registers attempt by key and reports whether it is allowed.
+func (l *attemptLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.sweep(now)
+	a, ok := l.attempts[key]
+	if !ok {
+		a = &attempts{start: now}
+		l.attempts[key] = a
+	}
+	if a.count >= l.limit {
+		return false
+	}
+	a.count++
+	return true
+}
+
+// sweep removes attempts which windows are ended, so limiter keeps only keys which were used recently.
+// Caller must hold lock.
+func (l *attemptLimiter) sweep(now time.Time) {
+	for key, a := range l.attempts {
+		if now.Sub(a.start) >= l.window {
+			delete(l.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// maxRedirectHops is max count of short urls in chain of short urls which point to each other.
+const maxRedirectHops = 5
+
+// self is set of hosts which serve short urls: host of base url and alias domains.
+type self struct {
+	hosts map[string]bool
+	// path is path of base url which is prefix of every short url.
+	path string
+}
+
+// newSelf ...
+func newSelf(baseURL string, aliases []string) *self {
+	s := &self{hosts: make(map[string]bool)}
+	if u, err := url.Parse(model.Canonicalize(baseURL, true)); err == nil {
+		s.hosts[u.Host] = true
+		s.path = strings.TrimSuffix(u.Path, "/")
+	}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		if !strings.Contains(alias, "://") {
+			alias = "http://" + alias
+		}
+		if u, err := url.Parse(model.Canonicalize(alias, true)); err == nil {
+			s.hosts[u.Host] = true
+		}
+	}
+	return s
+}
+
+// shortID reports whether dest points to service and returns id of short url which dest points to. Id is
+// empty if dest points to service, but isn't short url.
+func (s *self) shortID(dest string) (string, bool) {
+	u, err := url.Parse(model.Canonicalize(dest, true))
+	if err != nil || !s.hosts[u.Host] {
+		return "", false
+	}
+	path := strings.TrimPrefix(u.Path, s.path+"/")
+	if path == u.Path || path == "" || strings.Contains(path, "/") || u.RawQuery != "" {
+		return "", true
+	}
+	return path, true
+}
+
+// resolveSelfReference returns final original url of dest if dest points to short url of service, so short
+// url which is created with it isn't chain of redirects. Dest is rejected if it points to service but isn't
+// short url, short url can't be opened or isn't static, and if chain leads to short url with id.
+func (s *Service) resolveSelfReference(ctx context.Context, dest, id string) (string, error) {
+	for hop := 0; ; hop++ {
+		next, ok := s.self.shortID(dest)
+		switch {
+		case !ok:
+			return dest, nil
+		case next == "":
+			return "", ErrSelfReference
+		case next == id, hop == maxRedirectHops:
+			return "", ErrRedirectLoop
+		}
+		u, err := s.store.GetByID(ctx, next)
+		switch {
+		case errors.Is(err, store.ErrNotFound), errors.Is(err, store.ErrIsDeleted), errors.Is(err, store.ErrExpired):
+			return "", ErrSelfReference
+		case err != nil:
+			return "", fmt.Errorf("store: get by id: %w", err)
+		case !u.IsStatic():
+			return "", ErrSelfReference
+		}
+		dest = u.BaseURL
+	}
+}
+
+// resolveDestinations replaces original url of url which points to short url of service with final original
+// url. Rules and variants choose destination for every visitor, so their destinations are rejected if they
+// point to service.
+func (s *Service) resolveDestinations(ctx context.Context, u *model.URL) error {
+	dest, err := s.resolveSelfReference(ctx, u.BaseURL, u.ID)
+	if err != nil {
+		return err
+	}
+	if dest != u.BaseURL {
+		u.BaseURL, u.RawURL = dest, ""
+	}
+	return s.checkDestinations(u)
+}
+
+// checkDestinations returns ErrSelfReference if destination of rule or variant of url points to service.
+func (s *Service) checkDestinations(u *model.URL) error {
+	for _, dest := range u.Destinations()[1:] {
+		if _, ok := s.self.shortID(dest); ok {
+			return ErrSelfReference
+		}
+	}
+	return nil
+}
+
+// pointsToSelf reports whether any destination of url points to short url of service. Only such urls may
+// be part of redirect loop.
+func (s *Service) pointsToSelf(u *model.URL) bool {
+	for _, dest := range u.Destinations() {
+		if id, ok := s.self.shortID(dest); ok && id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLoop returns ErrRedirectLoop if url leads back to itself through short urls of service or chain of
+// short urls is longer than maxRedirectHops. Such urls may be left from time when they were not checked.
+func (s *Service) checkLoop(ctx context.Context, u *model.URL) error {
+	return s.walk(ctx, u, map[string]bool{u.ID: true}, 0)
+}
+
+// walk walks through short urls which url points to. Path contains ids of short urls of current chain.
+func (s *Service) walk(ctx context.Context, u *model.URL, path map[string]bool, depth int) error {
+	for _, dest := range u.Destinations() {
+		id, ok := s.self.shortID(dest)
+		switch {
+		case !ok, id == "":
+			continue
+		case path[id], depth == maxRedirectHops:
+			return ErrRedirectLoop
+		}
+		next, err := s.store.GetByID(ctx, id)
+		if err != nil {
+			// short url which can't be opened ends chain.
+			continue
+		}
+		path[id] = true
+		err = s.walk(ctx, next, path, depth+1)
+		delete(path, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
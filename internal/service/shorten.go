@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/vlad-marlo/shortener/internal/config"
+	"github.com/vlad-marlo/shortener/internal/domainpolicy"
+	"github.com/vlad-marlo/shortener/internal/idgen"
 	"github.com/vlad-marlo/shortener/internal/poll"
 	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
@@ -19,25 +23,71 @@ type Service struct {
 	poller *poll.Poll
 	store  store.Store
 	config *config.Config
+	gen    idgen.Generator
+	// attempts limits password attempts of protected urls.
+	attempts *attemptLimiter
+	// policy is blocklist and allowlist of domains; it is nil if domain policy file is not configured.
+	policy *domainpolicy.Policy
+	// urlPolicy defines which original urls and destinations of rules and variants are valid.
+	urlPolicy model.URLPolicy
+	// self is set of hosts of service which is used to find original urls which point to short urls.
+	self *self
 }
 
-// New ...
-func New(logger *zap.Logger, store store.Store) *Service {
-	return &Service{
+// New returns service which uses store. Error is returned if id generator or domain policy of config can't
+// be initialized.
+func New(logger *zap.Logger, store store.Store) (*Service, error) {
+	s := &Service{
 		logger: logger,
-		poller: poll.New(store, logger),
 		store:  store,
 		config: config.Get(),
 	}
+	gen, err := idgen.New(s.config.IDGenerator, s.config.IDLength, s.config.IDSalt, store)
+	if err != nil {
+		return nil, fmt.Errorf("init id generator: %w", err)
+	}
+	s.gen = gen
+	if s.config.DomainPolicyFile != "" {
+		if s.policy, err = domainpolicy.New(s.config.DomainPolicyFile, logger); err != nil {
+			return nil, fmt.Errorf("init domain policy: %w", err)
+		}
+		s.policy.Watch(s.config.DomainPolicyReloadInterval)
+	}
+	s.self = newSelf(s.config.BaseURL, s.config.AliasDomains)
+	s.attempts = newAttemptLimiter(s.config.PasswordAttempts, s.config.PasswordAttemptsWindow)
+	s.urlPolicy = model.URLPolicy{
+		Schemes:            s.config.AllowedSchemes,
+		MaxLength:          s.config.MaxURLLength,
+		RejectPrivateHosts: s.config.RejectPrivateHosts,
+	}
+	s.poller = poll.New(store, logger)
+	s.poller.StartSweeping(s.config.SweepInterval)
+	return s, nil
 }
 
 // CreateURL ...
-func (s *Service) CreateURL(ctx context.Context, user, url string) (*model.URL, error) {
-	u, err := s.NewURL(url, user)
+func (s *Service) CreateURL(ctx context.Context, user, url string, opts ...model.URLOption) (*model.URL, error) {
+	u, err := s.NewURL(ctx, url, user)
 	if err != nil {
 		return nil, fmt.Errorf("model: new url: %w", err)
 	}
+	if err = u.Apply(append(opts, s.canonical())...); err != nil {
+		return nil, fmt.Errorf("model: apply options: %w", err)
+	}
+	if err = u.Validate(s.urlPolicy); err != nil {
+		return nil, fmt.Errorf("model: validate url: %w", err)
+	}
+	if err = s.resolveDestinations(ctx, u); err != nil {
+		return nil, err
+	}
+	if err = s.checkDomains(u); err != nil {
+		return nil, err
+	}
 	if err = s.store.Create(ctx, u); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			// id of existing url is returned, so client is able to use it.
+			return u, fmt.Errorf("store: create url: %w", err)
+		}
 		return nil, fmt.Errorf("store: create url: %w", err)
 	}
 	return u, nil
@@ -48,24 +98,47 @@ func (s *Service) DeleteManyURLs(user string, urls []string) {
 	s.poller.DeleteURLs(urls, user)
 }
 
-// GetAllURLsByUser ...
-func (s *Service) GetAllURLsByUser(ctx context.Context, user string) ([]*model.AllUserURLsResponse, error) {
-	urls, err := s.store.GetAllUserURLs(ctx, user)
+// ListURLsByUser returns page of urls which are created by user and cursor of next page, which is empty if
+// page is last.
+func (s *Service) ListURLsByUser(ctx context.Context, user string, opts store.ListOptions) ([]*model.AllUserURLsResponse, string, error) {
+	page, err := s.store.ListUserURLs(ctx, user, opts)
 	if err != nil {
-		return nil, fmt.Errorf("store: get all user urls: %w", err)
+		return nil, "", fmt.Errorf("store: list user urls: %w", err)
 	}
-	var responseURLs []*model.AllUserURLsResponse
-	for _, u := range urls {
-		if err = ctx.Err(); err != nil {
-			return nil, err
-		}
-		resp := &model.AllUserURLsResponse{
-			ShortURL:    fmt.Sprintf("%s/%s", s.config.BaseURL, u.ID),
-			OriginalURL: u.BaseURL,
-		}
-		responseURLs = append(responseURLs, resp)
+	responseURLs := make([]*model.AllUserURLsResponse, 0, len(page.URLs))
+	for _, u := range page.URLs {
+		responseURLs = append(responseURLs, s.userURLResponse(u))
 	}
-	return responseURLs, nil
+	return responseURLs, page.NextCursor, nil
+}
+
+// userURLResponse ...
+func (s *Service) userURLResponse(u *model.URL) *model.AllUserURLsResponse {
+	resp := &model.AllUserURLsResponse{
+		ShortURL:     fmt.Sprintf("%s/%s", s.config.BaseURL, u.ID),
+		OriginalURL:  u.Display(),
+		Title:        u.Title,
+		Note:         u.Note,
+		Tags:         u.Tags,
+		RedirectCode: u.Redirect(),
+		Protected:    u.IsProtected(),
+		Rules:        u.Rules,
+		Variants:     u.Variants,
+		Passthrough:  u.Passthrough,
+		UTM:          u.UTM,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+		DeletedAt:    u.DeletedAt,
+	}
+	if u.RawURL != "" {
+		resp.CanonicalURL = u.BaseURL
+	}
+	if u.IsLimited() {
+		clicksLeft := u.ClicksLeft
+		resp.MaxClicks = u.MaxClicks
+		resp.ClicksLeft = &clicksLeft
+	}
+	return resp
 }
 
 // Ping ...
@@ -83,10 +156,22 @@ func (s *Service) CreateManyURLs(ctx context.Context, user string, urls []model.
 		if err := ctx.Err(); err != nil {
 			return nil, fmt.Errorf("context: %w", err)
 		}
-		url, err := s.NewURL(i.GetOriginalUrl(), user, i.GetCorrelationId())
+		url, err := s.NewURL(ctx, i.GetOriginalUrl(), user, i.GetCorrelationId())
 		if err != nil {
 			return nil, fmt.Errorf("model: url: %w", err)
 		}
+		if err = url.Apply(append(model.OptionsOf(i), s.canonical())...); err != nil {
+			return nil, fmt.Errorf("model: apply options: %w", err)
+		}
+		if err = url.Validate(s.urlPolicy); err != nil {
+			return nil, fmt.Errorf("model: validate url: %w", err)
+		}
+		if err = s.resolveDestinations(ctx, url); err != nil {
+			return nil, err
+		}
+		if err = s.checkDomains(url); err != nil {
+			return nil, err
+		}
 		u = append(u, url)
 	}
 
@@ -102,33 +187,217 @@ func (s *Service) CreateManyURLs(ctx context.Context, user string, urls []model.
 	return resp, nil
 }
 
-// NewURL ...
-func (s *Service) NewURL(url, user string, correlationID ...string) (*model.URL, error) {
-	return model.NewURL(url, user, correlationID...)
+// canonical returns option which replaces original url and destinations of rules and variants with their
+// canonical forms, so duplicates are found by canonical forms of urls. It must be applied after other
+// options.
+func (s *Service) canonical() model.URLOption {
+	return model.WithCanonicalURL(s.config.NormalizeDropFragment)
+}
+
+// checkDomains returns error if domain of any destination of url is blocked by domain policy.
+func (s *Service) checkDomains(u *model.URL) error {
+	for _, dest := range u.Destinations() {
+		if err := s.policy.Check(dest); err != nil {
+			return fmt.Errorf("domain policy: %w", err)
+		}
+	}
+	return nil
 }
 
-// GetByID ...
+// NewURL creates url with id generated by configured generator.
+func (s *Service) NewURL(ctx context.Context, url, user string, correlationID ...string) (*model.URL, error) {
+	return model.NewURLWithGenerator(ctx, s.gen, url, user, correlationID...)
+}
+
+// GetByID returns url with provided id. Url is checked by domain policy again, so urls which were created
+// before domain was blocked are not opened.
 func (s *Service) GetByID(ctx context.Context, id string) (*model.URL, error) {
-	return s.store.GetByID(ctx, id)
+	u, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkDomains(u); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
-// GetInternalStats ...
-func (s *Service) GetInternalStats(ctx context.Context, ip string) (*model.InternalStat, error) {
-	network, err := netip.ParsePrefix(s.config.TrustedIP)
+// OpenURL returns url with provided id to redirect to it. Url which is protected with password is returned
+// only if password is correct; count of password attempts of one url is limited. Url which is blocked by
+// domain policy or leads back to itself through other short urls is not opened. Every opening of url with
+// limited clicks takes one click.
+func (s *Service) OpenURL(ctx context.Context, id, password string) (*model.URL, error) {
+	u, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// most of urls point to other hosts, so chain is walked only for urls which point to service.
+	if s.pointsToSelf(u) {
+		if err = s.checkLoop(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case u.IsExhausted():
+		return nil, store.ErrExhausted
+	case !u.IsProtected():
+	case password == "":
+		return nil, ErrPasswordRequired
+	case !s.attempts.Allow(id):
+		return nil, ErrTooManyAttempts
+	case !u.CheckPassword(password):
+		return nil, ErrWrongPassword
+	}
+	if !u.IsLimited() {
+		return u, nil
+	}
+	if u, err = s.store.TakeClick(ctx, id); err != nil {
+		return nil, fmt.Errorf("store: take click: %w", err)
+	}
+	return u, nil
+}
+
+// RecordClick saves click on short url asynchronously.
+func (s *Service) RecordClick(c *model.Click) {
+	s.poller.RecordClick(c)
+}
+
+// GetLinkStats returns click stats of url. Only user which created url has access to its stats.
+func (s *Service) GetLinkStats(ctx context.Context, user, id string) (*model.LinkStats, error) {
+	// urls which were created without user have no owner, so their stats are available to nobody.
+	if user == "" {
+		return nil, ErrForbidden
+	}
+	stats, err := s.store.GetLinkStats(ctx, id)
 	if err != nil {
+		return nil, fmt.Errorf("store: get link stats: %w", err)
+	}
+	if stats.User != user {
 		return nil, ErrForbidden
 	}
+	return stats, nil
+}
 
-	netip, err := netip.ParseAddr(ip)
-	if err != nil || !network.Contains(netip) {
+// ownURL returns url with provided id if it is created by user.
+func (s *Service) ownURL(ctx context.Context, user, id string) (*model.URL, error) {
+	// urls which were created without user have no owner, so nobody is able to change them.
+	if user == "" {
 		return nil, ErrForbidden
 	}
+	u, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: get by id: %w", err)
+	}
+	if u.User != user {
+		return nil, ErrForbidden
+	}
+	return u, nil
+}
+
+// UpdateURL changes original url of url if url isn't empty and applies options to it. Only user which
+// created url is able to change it.
+func (s *Service) UpdateURL(ctx context.Context, user, id, url string, opts ...model.URLOption) (*model.AllUserURLsResponse, error) {
+	u, err := s.ownURL(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	if url != "" {
+		// new original url is canonicalized as on creation, so it is deduplicated by its canonical form.
+		dest := model.Canonicalize(url, s.config.NormalizeDropFragment)
+		resolved, err := s.resolveSelfReference(ctx, dest, id)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != dest {
+			url = ""
+		}
+		opts = append([]model.URLOption{model.WithDestination(resolved, url)}, opts...)
+	}
+	if len(opts) == 0 {
+		return s.userURLResponse(u), nil
+	}
+	// destinations of new rules and variants are canonicalized as original url is.
+	opts = append(opts, model.WithCanonicalDestinations(s.config.NormalizeDropFragment))
+	// options are applied to copy of url to check destinations before url is changed in store.
+	updated := *u
+	if err = updated.Apply(opts...); err != nil {
+		return nil, fmt.Errorf("model: apply options: %w", err)
+	}
+	if err = updated.Validate(s.urlPolicy); err != nil {
+		return nil, fmt.Errorf("model: validate url: %w", err)
+	}
+	if err = s.checkDestinations(&updated); err != nil {
+		return nil, err
+	}
+	if err = s.checkDomains(&updated); err != nil {
+		return nil, err
+	}
+	if u, err = s.store.UpdateURL(ctx, id, opts...); err != nil {
+		return nil, fmt.Errorf("store: update url: %w", err)
+	}
+	return s.userURLResponse(u), nil
+}
 
+// GetHistory returns previous original urls of url. Only user which created url has access to its history.
+func (s *Service) GetHistory(ctx context.Context, user, id string) ([]*model.Destination, error) {
+	if _, err := s.ownURL(ctx, user, id); err != nil {
+		return nil, err
+	}
+	history, err := s.store.GetHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: get history: %w", err)
+	}
+	return history, nil
+}
+
+// isTrusted reports whether ip belongs to trusted subnet.
+func (s *Service) isTrusted(ip string) bool {
+	network, err := netip.ParsePrefix(s.config.TrustedIP)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && network.Contains(addr)
+}
+
+// GetInternalStats ...
+func (s *Service) GetInternalStats(ctx context.Context, ip string) (*model.InternalStat, error) {
+	if !s.isTrusted(ip) {
+		return nil, ErrForbidden
+	}
 	return s.store.GetData(ctx)
 }
 
+// RestoreManyURLs restores deleted urls of user and returns count of restored urls.
+func (s *Service) RestoreManyURLs(ctx context.Context, user string, ids []string) (int64, error) {
+	// urls which were created without user have no owner, so nobody is able to restore them.
+	if user == "" {
+		return 0, ErrForbidden
+	}
+	n, err := s.store.URLsBulkRestore(ctx, ids, user)
+	if err != nil {
+		return 0, fmt.Errorf("store: urls bulk restore: %w", err)
+	}
+	return n, nil
+}
+
+// PurgeDeleted physically removes urls which were deleted more than olderThan ago. Only requests from
+// trusted subnet are allowed to purge urls.
+func (s *Service) PurgeDeleted(ctx context.Context, ip string, olderThan time.Duration) (int64, error) {
+	if !s.isTrusted(ip) {
+		return 0, ErrForbidden
+	}
+	n, err := s.store.PurgeDeleted(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("store: purge deleted: %w", err)
+	}
+	s.logger.Info("purged deleted urls", zap.Int64("count", n), zap.Duration("older_than", olderThan))
+	return n, nil
+}
+
 // Close ...
 func (s *Service) Close() error {
 	s.poller.Close()
+	s.policy.Close()
 	return s.store.Close()
 }
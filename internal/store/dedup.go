@@ -0,0 +1,67 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// DedupScope defines which urls are duplicates of each other. Creation of duplicate url doesn't create
+// new url: id of existing one is returned with ErrAlreadyExists.
+type DedupScope string
+
+// const ...
+const (
+	// DedupGlobal makes urls with same original url duplicates regardless of user which created them.
+	DedupGlobal DedupScope = "global"
+	// DedupUser makes urls with same original url duplicates only if they are created by same user,
+	// so every user owns their own links.
+	DedupUser DedupScope = "user"
+	// DedupNone disables deduplication: every creation creates new url.
+	DedupNone DedupScope = "none"
+)
+
+// ErrUnknownDedupScope ...
+var ErrUnknownDedupScope = errors.New("dedup scope must be one of global, user, none")
+
+// ParseDedupScope returns scope with provided name. Empty name means DedupGlobal.
+func ParseDedupScope(name string) (DedupScope, error) {
+	switch scope := DedupScope(name); scope {
+	case "":
+		return DedupGlobal, nil
+	case DedupGlobal, DedupUser, DedupNone:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("%q: %w", name, ErrUnknownDedupScope)
+	}
+}
+
+// Deduplicated reports whether url takes part in deduplication. Only static urls are deduplicated: url
+// with password, expiration, clicks limit, rules, variants or query settings is never duplicate. Existing
+// url without these settings must not be returned instead of url which asks for them, and url with them
+// must not be returned to client which didn't ask for them.
+func Deduplicated(u *model.URL) bool {
+	return u.IsStatic()
+}
+
+// Key returns key which is equal for urls which are duplicates in scope. Empty key means that url has no
+// duplicates. Zero scope is DedupGlobal.
+func (d DedupScope) Key(u *model.URL) string {
+	switch {
+	case d == DedupNone, !Deduplicated(u):
+		return ""
+	case d == DedupUser:
+		// original url never contains spaces, so keys of different users never collide
+		// with each other or with global keys.
+		return u.User + " " + u.BaseURL
+	default:
+		return u.BaseURL
+	}
+}
+
+// IsDuplicate reports whether existing url is duplicate of u in scope.
+func (d DedupScope) IsDuplicate(u, existing *model.URL) bool {
+	key := d.Key(u)
+	return key != "" && key == d.Key(existing)
+}
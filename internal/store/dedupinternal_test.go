@@ -0,0 +1,74 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+func TestParseDedupScope(t *testing.T) {
+	for name, want := range map[string]DedupScope{
+		"":       DedupGlobal,
+		"global": DedupGlobal,
+		"user":   DedupUser,
+		"none":   DedupNone,
+	} {
+		got, err := ParseDedupScope(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseDedupScope("per-request")
+	assert.ErrorIs(t, err, ErrUnknownDedupScope)
+}
+
+func TestDedupScope_IsDuplicate(t *testing.T) {
+	a := &model.URL{BaseURL: "https://example.org", User: "a"}
+	sameUser := &model.URL{BaseURL: "https://example.org", User: "a"}
+	otherUser := &model.URL{BaseURL: "https://example.org", User: "b"}
+	otherURL := &model.URL{BaseURL: "https://example.com", User: "a"}
+
+	tt := []struct {
+		scope     DedupScope
+		sameUser  bool
+		otherUser bool
+	}{
+		{scope: "", sameUser: true, otherUser: true},
+		{scope: DedupGlobal, sameUser: true, otherUser: true},
+		{scope: DedupUser, sameUser: true, otherUser: false},
+		{scope: DedupNone, sameUser: false, otherUser: false},
+	}
+	for _, tc := range tt {
+		t.Run(string(tc.scope), func(t *testing.T) {
+			assert.Equal(t, tc.sameUser, tc.scope.IsDuplicate(a, sameUser))
+			assert.Equal(t, tc.otherUser, tc.scope.IsDuplicate(a, otherUser))
+			assert.False(t, tc.scope.IsDuplicate(a, otherURL))
+		})
+	}
+}
+
+func TestDedupScope_IsDuplicate_Settings(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	plain := &model.URL{BaseURL: "https://example.org", User: "a"}
+	for name, u := range map[string]*model.URL{
+		"protected":   {PasswordHash: "hash"},
+		"expiring":    {ExpiresAt: &expires},
+		"limited":     {MaxClicks: 1, ClicksLeft: 1},
+		"rules":       {Rules: []*model.Rule{{Device: model.DeviceIOS, Destination: "https://apps.apple.com"}}},
+		"variants":    {Variants: []*model.Variant{{Name: "a", Destination: "https://example.org/a", Weight: 1}}},
+		"passthrough": {Passthrough: true},
+		"utm":         {UTM: &model.UTM{Source: "news"}},
+	} {
+		u.BaseURL, u.User = plain.BaseURL, plain.User
+		t.Run(name, func(t *testing.T) {
+			for _, scope := range []DedupScope{DedupGlobal, DedupUser} {
+				assert.Empty(t, scope.Key(u))
+				assert.False(t, scope.IsDuplicate(u, plain))
+				assert.False(t, scope.IsDuplicate(plain, u))
+				assert.False(t, scope.IsDuplicate(u, u))
+			}
+		})
+	}
+}
@@ -16,4 +16,12 @@ var (
 	ErrNotAccessible = errors.New("not accessible")
 	// ErrAlreadyClosed ...
 	ErrAlreadyClosed = errors.New("storage is already closed")
+	// ErrExpired ...
+	ErrExpired = errors.New("is expired")
+	// ErrExhausted ...
+	ErrExhausted = errors.New("clicks are exhausted")
+	// ErrAliasTaken ...
+	ErrAliasTaken = errors.New("alias is already taken")
+	// ErrIDCollision ...
+	ErrIDCollision = errors.New("unable to generate id which is not taken")
 )
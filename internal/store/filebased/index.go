@@ -0,0 +1,236 @@
+package filebased
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// entry is index entry of url record which is stored in file.
+type entry struct {
+	offset    int64
+	user      string
+	url       string
+	tags      []string
+	createdAt time.Time
+	updatedAt time.Time
+	deleted   bool
+	deletedAt *time.Time
+	swept     bool
+	expiresAt *time.Time
+	history   []*model.Destination
+	// clicksLeft is remaining clicks of url, which are decremented by click records.
+	clicksLeft int64
+	// dedup reports whether url takes part in deduplication.
+	dedup bool
+}
+
+// deletedBefore is same as model.URL.DeletedBefore.
+func (e *entry) deletedBefore(t time.Time) bool {
+	return e.deleted && (e.deletedAt == nil || e.deletedAt.Before(t))
+}
+
+// touch sets modification time of entry. Records which were written before time of operations was
+// recorded have no time, so modification time is kept.
+func (e *entry) touch(at *time.Time) {
+	if at != nil {
+		e.updatedAt = *at
+	}
+}
+
+// isExpired ...
+func (e *entry) isExpired() bool {
+	return e.expiresAt != nil && !e.expiresAt.After(time.Now())
+}
+
+// index maps url ids to offsets of their records in file, users to ids of urls which they created and
+// original urls to ids of urls which are shortening them.
+type index struct {
+	urls  map[string]*entry
+	users map[string][]string
+	byURL map[string][]string
+	// seq is max counter of id generators which was written to file.
+	seq uint64
+	// records is count of records which are applied to index.
+	records int
+}
+
+// newIndex ...
+func newIndex() *index {
+	return &index{
+		urls:  make(map[string]*entry),
+		users: make(map[string][]string),
+		byURL: make(map[string][]string),
+	}
+}
+
+// buildIndex reads all records of r and returns index of them with size of read data.
+func buildIndex(r io.Reader) (*index, int64, error) {
+	idx := newIndex()
+	reader := bufio.NewReader(r)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			rec := new(record)
+			if decodeErr := json.Unmarshal(line, rec); decodeErr != nil {
+				return nil, 0, fmt.Errorf("decode record at %d: %w", offset, decodeErr)
+			}
+			idx.add(rec, offset)
+			offset += int64(len(line))
+		}
+		if err == io.EOF {
+			return idx, offset, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// add applies record which is stored at offset to index.
+func (i *index) add(r *record, offset int64) {
+	i.records++
+	if r.Seq > i.seq {
+		i.seq = r.Seq
+	}
+	switch {
+	case r.Op == opDelete:
+		for _, id := range r.IDs {
+			if e, ok := i.urls[id]; ok {
+				e.deleted = true
+				e.deletedAt = r.At
+				e.touch(r.At)
+			}
+		}
+	case r.Op == opRestore:
+		for _, id := range r.IDs {
+			if e, ok := i.urls[id]; ok {
+				e.deleted = false
+				e.deletedAt = nil
+				e.touch(r.At)
+			}
+		}
+	case r.Op == opPurge:
+		for _, id := range r.IDs {
+			i.remove(id)
+		}
+	case r.Op == opSweep:
+		for _, id := range r.IDs {
+			if e, ok := i.urls[id]; ok {
+				e.swept = true
+				e.touch(r.At)
+			}
+		}
+	case r.Op == opUpdate:
+		for _, id := range r.IDs {
+			if e, ok := i.urls[id]; ok && r.At != nil {
+				e.history = append(e.history, &model.Destination{OriginalURL: e.url, ReplacedAt: *r.At})
+				i.unindexURL(e.url, id)
+				e.url = r.Destination
+				i.byURL[e.url] = append(i.byURL[e.url], id)
+				e.touch(r.At)
+			}
+		}
+	case r.Op == opClick:
+		for _, id := range r.IDs {
+			if e, ok := i.urls[id]; ok {
+				e.clicksLeft--
+			}
+		}
+	case r.URL != nil:
+		// record of existing url replaces it, so url is indexed again if its original url is changed.
+		prev, ok := i.urls[r.URL.ID]
+		switch {
+		case !ok:
+			i.users[r.URL.User] = append(i.users[r.URL.User], r.URL.ID)
+			i.byURL[r.URL.BaseURL] = append(i.byURL[r.URL.BaseURL], r.URL.ID)
+		case prev.url != r.URL.BaseURL:
+			i.unindexURL(prev.url, r.URL.ID)
+			i.byURL[r.URL.BaseURL] = append(i.byURL[r.URL.BaseURL], r.URL.ID)
+		}
+		i.urls[r.URL.ID] = &entry{
+			offset:     offset,
+			user:       r.URL.User,
+			url:        r.URL.BaseURL,
+			tags:       r.URL.Tags,
+			createdAt:  r.URL.CreatedAt,
+			updatedAt:  r.URL.UpdatedAt,
+			deleted:    r.Deleted,
+			deletedAt:  r.DeletedAt,
+			swept:      r.Swept,
+			expiresAt:  r.URL.ExpiresAt,
+			history:    r.History,
+			clicksLeft: r.URL.ClicksLeft,
+			dedup:      store.Deduplicated(r.URL),
+		}
+	}
+}
+
+// stale returns count of records which are overwritten by later records or fold into url records.
+func (i *index) stale() int {
+	return i.records - len(i.urls)
+}
+
+// remove removes url with provided id from index.
+func (i *index) remove(id string) {
+	e, ok := i.urls[id]
+	if !ok {
+		return
+	}
+	delete(i.urls, id)
+	if i.users[e.user] = removeID(i.users[e.user], id); len(i.users[e.user]) == 0 {
+		delete(i.users, e.user)
+	}
+	i.unindexURL(e.url, id)
+}
+
+// unindexURL removes id from ids of urls which are shortening original url.
+func (i *index) unindexURL(url, id string) {
+	if i.byURL[url] = removeID(i.byURL[url], id); len(i.byURL[url]) == 0 {
+		delete(i.byURL, url)
+	}
+}
+
+// removeID returns ids without id.
+func removeID(ids []string, id string) []string {
+	res := ids[:0]
+	for _, i := range ids {
+		if i != id {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// readURL reads url record from r by offset of entry.
+func readURL(r io.ReaderAt, e *entry, size int64) (*model.URL, error) {
+	line, err := bufio.NewReader(io.NewSectionReader(r, e.offset, size-e.offset)).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	rec := new(record)
+	if err = json.Unmarshal(line, rec); err != nil {
+		return nil, fmt.Errorf("decode record at %d: %w", e.offset, err)
+	}
+	if rec.URL == nil {
+		return nil, fmt.Errorf("record at %d is not url", e.offset)
+	}
+	// original url of record is initial one, it may be changed by update records. Raw url belongs to
+	// initial original url only.
+	if rec.URL.BaseURL != e.url {
+		rec.URL.RawURL = ""
+	}
+	rec.URL.BaseURL = e.url
+	rec.URL.IsDeleted = e.deleted
+	rec.URL.DeletedAt = e.deletedAt
+	rec.URL.IsSwept = e.swept
+	rec.URL.UpdatedAt = e.updatedAt
+	rec.URL.ClicksLeft = e.clicksLeft
+	return rec.URL, nil
+}
@@ -1,14 +1,60 @@
 package filebased
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
+	"time"
 
-	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
+// const ...
+const (
+	// opDelete is operation of record which marks urls with provided ids as deleted.
+	opDelete = "delete"
+	// opRestore is operation of record which removes deletion mark of urls with provided ids.
+	opRestore = "restore"
+	// opPurge is operation of record which removes urls with provided ids.
+	opPurge = "purge"
+	// opUpdate is operation of record which changes original url of url with provided id to destination.
+	// Changed urls are written as whole records now, update records of older files are still read.
+	opUpdate = "update"
+	// opClick is operation of record which decrements remaining clicks of urls with provided ids.
+	opClick = "click"
+	// opSweep is operation of record which marks expired urls with provided ids as swept.
+	opSweep = "sweep"
+	// opSequence is operation of record which keeps counter of id generators in compacted file.
+	opSequence = "sequence"
+)
+
+// record is one line of storage file.
+//
+// Record without operation is created url or changed url which replaces previous record of url with same
+// id. Files which were written before operations were introduced contain only urls, so they are still
+// readable.
+type record struct {
+	*model.URL
+	Op        string     `json:"op,omitempty"`
+	IDs       []string   `json:"ids,omitempty"`
+	At        *time.Time `json:"at,omitempty"`
+	Deleted   bool       `json:"deleted,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Swept is sweeping mark of url record which is folded into it by compaction.
+	Swept bool `json:"swept,omitempty"`
+
+	// Destination is new original url of update record.
+	Destination string `json:"destination,omitempty"`
+	// History is previous original urls of url record which are folded into it by compaction.
+	History []*model.Destination `json:"history,omitempty"`
+	// Seq is counter of id generators at time when record was written. Counter is restored from max seq of
+	// records, so generated ids are never reused.
+	Seq uint64 `json:"seq,omitempty"`
+}
+
 // producer ...
 type producer struct {
 	file    *os.File
@@ -29,45 +75,244 @@ func newProducer(filename string) (*producer, error) {
 	}, nil
 }
 
-// CreateURL ...
-func (p *producer) CreateURL(u *model.URL) error {
-	return p.encoder.Encode(&u)
+// encodeRecords encodes every record into separate line and returns data with offset of every record in it.
+func encodeRecords(records ...*record) ([]byte, []int64, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	offsets := make([]int64, 0, len(records))
+	for _, r := range records {
+		offsets = append(offsets, int64(buf.Len()))
+		if err := enc.Encode(r); err != nil {
+			return nil, nil, err
+		}
+	}
+	return buf.Bytes(), offsets, nil
 }
 
-// GetURLByID ...
-func (p *producer) GetURLByID(id string) (u *model.URL, err error) {
+// replay decodes all records of file and calls fn for every record.
+func (p *producer) replay(fn func(r *record)) error {
 	for {
-		err = p.decoder.Decode(&u)
-		if u != nil && u.ID == id {
-			return u, nil
-		}
-		if err != nil {
+		r := new(record)
+		if err := p.decoder.Decode(r); err != nil {
 			if err == io.EOF {
-				return nil, store.ErrNotFound
+				return nil
 			}
-			return nil, err
+			return err
 		}
+		fn(r)
 	}
 }
 
-// GetAllUserURLs ...
-func (p *producer) GetAllUserURLs(user string) (urls []*model.URL, err error) {
-	var u *model.URL
-	for {
-		err = p.decoder.Decode(&u)
-		if u != nil && u.User == user {
+// GetURLs returns records of all urls from file with applied operations in order of creation and max
+// counter of id generators which was written to file.
+func (p *producer) GetURLs() (urls []*record, seq uint64, err error) {
+	byID := make(map[string]*record)
+	var ids []string
+	err = p.replay(func(r *record) {
+		if r.Seq > seq {
+			seq = r.Seq
+		}
+		switch {
+		case r.Op == opDelete:
+			for _, id := range r.IDs {
+				if u, ok := byID[id]; ok {
+					u.Deleted = true
+					u.DeletedAt = r.At
+					touch(u.URL, r.At)
+				}
+			}
+		case r.Op == opRestore:
+			for _, id := range r.IDs {
+				if u, ok := byID[id]; ok {
+					u.Deleted = false
+					u.DeletedAt = nil
+					touch(u.URL, r.At)
+				}
+			}
+		case r.Op == opPurge:
+			for _, id := range r.IDs {
+				delete(byID, id)
+			}
+		case r.Op == opSweep:
+			for _, id := range r.IDs {
+				if u, ok := byID[id]; ok {
+					u.Swept = true
+					touch(u.URL, r.At)
+				}
+			}
+		case r.Op == opUpdate:
+			for _, id := range r.IDs {
+				if u, ok := byID[id]; ok && r.At != nil {
+					u.History = append(u.History, &model.Destination{OriginalURL: u.URL.BaseURL, ReplacedAt: *r.At})
+					u.URL.BaseURL = r.Destination
+					u.URL.RawURL = ""
+					touch(u.URL, r.At)
+				}
+			}
+		case r.Op == opClick:
+			for _, id := range r.IDs {
+				if u, ok := byID[id]; ok {
+					u.URL.ClicksLeft--
+				}
+			}
+		case r.URL != nil:
+			if _, ok := byID[r.URL.ID]; !ok {
+				ids = append(ids, r.URL.ID)
+			}
+			byID[r.URL.ID] = r
+		}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, id := range ids {
+		// url may be purged and created again, so every id is taken only once.
+		if u, ok := byID[id]; ok {
 			urls = append(urls, u)
+			delete(byID, id)
+		}
+	}
+	return urls, seq, nil
+}
+
+// touch sets modification time of url if time of operation is known.
+func touch(u *model.URL, at *time.Time) {
+	if at != nil {
+		u.UpdatedAt = *at
+	}
+}
+
+// SaveClick ...
+func (p *producer) SaveClick(c *model.Click) error {
+	return p.encoder.Encode(c)
+}
+
+// replayClicks decodes all clicks of file and calls fn for every click.
+func (p *producer) replayClicks(fn func(c *model.Click)) error {
+	for {
+		var c *model.Click
+		err := p.decoder.Decode(&c)
+		if c != nil {
+			fn(c)
 		}
 		if err != nil {
 			if err == io.EOF {
-				return urls, nil
+				return nil
 			}
-			return
+			return err
 		}
 	}
 }
 
+// GetClicks returns all clicks of url with provided id.
+func (p *producer) GetClicks(id string) (clicks []*model.Click, err error) {
+	err = p.replayClicks(func(c *model.Click) {
+		if c.URLID == id {
+			clicks = append(clicks, c)
+		}
+	})
+	return
+}
+
 // Close ...
 func (p *producer) Close() error {
 	return p.file.Close()
 }
+
+// Compact rewrites storage file so it contains only one record for every url. Tombstones are folded
+// into deleted flag of url records. Compact must be called only when file is not used by store:
+// file is replaced atomically with rename of temporary file.
+func Compact(filename string) error {
+	p, err := newProducer(filename)
+	if err != nil {
+		return err
+	}
+	urls, seq, err := p.GetURLs()
+	if closeErr := p.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, func(enc *json.Encoder) error {
+		for _, r := range urls {
+			if err := enc.Encode(&record{
+				URL:       r.URL,
+				Deleted:   r.Deleted,
+				DeletedAt: r.DeletedAt,
+				Swept:     r.Swept,
+				History:   r.History,
+			}); err != nil {
+				return err
+			}
+		}
+		if seq == 0 {
+			return nil
+		}
+		return enc.Encode(&record{Op: opSequence, Seq: seq})
+	})
+}
+
+// purgeClicks rewrites clicks file without clicks of urls with provided ids.
+func purgeClicks(filename string, ids map[string]struct{}) error {
+	p, err := newProducer(filename)
+	if err != nil {
+		return err
+	}
+	var clicks []*model.Click
+	err = p.replayClicks(func(c *model.Click) {
+		if _, ok := ids[c.URLID]; !ok {
+			clicks = append(clicks, c)
+		}
+	})
+	if closeErr := p.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, func(enc *json.Encoder) error {
+		for _, c := range clicks {
+			if err := enc.Encode(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeFileAtomic writes data with write to temporary file and replaces filename with it, so crash during
+// writing never corrupts file.
+func writeFileAtomic(filename string, write func(enc *json.Encoder) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	w := bufio.NewWriter(tmp)
+	if err = write(json.NewEncoder(w)); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(0664); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -3,20 +3,36 @@ package filebased
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"os"
 	"sync"
+	"time"
 
 	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
-// Store ...
+// Store keeps all urls in JSON-lines file and serves reads with in-memory index of records offsets.
 type Store struct {
 	Filename string
 	closed   bool
-	mu       sync.Mutex
+	mu       sync.RWMutex
+
+	file  *os.File
+	size  int64
+	index *index
+	// seq is counter of id generators. It is written to url records, so it is restored from max of them.
+	seq uint64
+
+	dedup store.DedupScope
+
+	clicksMu sync.Mutex
 }
 
+// compactStaleRecords is min count of stale records in file which makes store compact file on open.
+const compactStaleRecords = 1000
+
 // New ...
 func New(filename string) (*Store, error) {
 	if filename == "" {
@@ -26,76 +42,338 @@ func New(filename string) (*Store, error) {
 	s := &Store{
 		Filename: filename,
 	}
-	p, err := newProducer(s.Filename)
-	defer func() {
-		if err = p.Close(); err != nil {
-			log.Println(err)
-		}
-	}()
+	file, err := os.OpenFile(s.Filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0664)
 	if err != nil {
 		return nil, err
 	}
+	s.index, s.size, err = buildIndex(file)
+	if err != nil {
+		if closeErr := file.Close(); closeErr != nil {
+			log.Println(closeErr)
+		}
+		return nil, fmt.Errorf("build index: %w", err)
+	}
+	s.file = file
+	// file is compacted only if most of it are stale records, so opening of store doesn't rewrite
+	// whole file every time.
+	if stale := s.index.stale(); stale >= compactStaleRecords && 2*stale >= s.index.records {
+		if err = s.compact(); err != nil {
+			if closeErr := s.file.Close(); closeErr != nil {
+				log.Println(closeErr)
+			}
+			return nil, fmt.Errorf("compact: %w", err)
+		}
+	}
+	// files which were written before counter was persisted have no seq.
+	s.seq = s.index.seq
+	if n := uint64(len(s.index.urls)); n > s.seq {
+		s.seq = n
+	}
 	log.Print("successfully configured file-based store")
 	return s, nil
 }
 
+// appendRecords writes records to the end of file and applies them to index. Caller must hold write lock.
+func (s *Store) appendRecords(records ...*record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	for _, r := range records {
+		if r.URL != nil {
+			r.Seq = s.seq
+		}
+	}
+	data, offsets, err := encodeRecords(records...)
+	if err != nil {
+		return err
+	}
+	if _, err = s.file.Write(data); err != nil {
+		return err
+	}
+	for i, r := range records {
+		s.index.add(r, s.size+offsets[i])
+	}
+	s.size += int64(len(data))
+	return nil
+}
+
+// getURL reads url from file by index entry. Caller must hold lock.
+func (s *Store) getURL(id string) (*model.URL, error) {
+	e, ok := s.index.urls[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return readURL(s.file, e, s.size)
+}
+
 // GetByID ...
 func (s *Store) GetByID(_ context.Context, id string) (*model.URL, error) {
-	p, err := newProducer(s.Filename)
-	defer func() {
-		if err = p.Close(); err != nil {
-			log.Println(err)
-		}
-	}()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, err := s.getURL(id)
 	if err != nil {
 		return nil, err
 	}
-	return p.GetURLByID(id)
+	if u.IsDeleted {
+		return nil, store.ErrIsDeleted
+	}
+	if u.IsExpired() {
+		return nil, store.ErrExpired
+	}
+	return u, nil
 }
 
 // Create ...
-func (s *Store) Create(_ context.Context, u *model.URL) error {
-	p, err := newProducer(s.Filename)
-	defer func() {
-		if err = p.Close(); err != nil {
-			log.Println(err)
+func (s *Store) Create(ctx context.Context, u *model.URL) error {
+	return store.EnsureFreeIDs(ctx, func() ([]*model.URL, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if id, e := s.duplicate(u); e != nil {
+			u.ID = id
+			return nil, store.ErrAlreadyExists
+		}
+		free, err := store.FreeID(u, s.taken)
+		switch {
+		case err != nil:
+			return nil, err
+		case !free:
+			return []*model.URL{u}, nil
+		}
+		return nil, s.appendRecords(&record{URL: u})
+	})
+}
+
+// SetDedupScope ...
+func (s *Store) SetDedupScope(scope store.DedupScope) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedup = scope
+}
+
+// duplicate returns id and index entry of url which is duplicate of u in dedup scope of store. Deleted
+// urls have no duplicates, so url is created anew instead of deleted one. Caller must hold lock.
+func (s *Store) duplicate(u *model.URL) (string, *entry) {
+	for _, id := range s.index.byURL[u.BaseURL] {
+		e := s.index.urls[id]
+		if e.dedup && !e.deleted && s.dedup.IsDuplicate(u, &model.URL{BaseURL: u.BaseURL, User: e.user}) {
+			return id, e
 		}
-	}()
-	if err != nil {
-		return err
 	}
-	return p.CreateURL(u)
+	return "", nil
 }
 
-// GetAllUserURLs ...
-func (s *Store) GetAllUserURLs(_ context.Context, user string) ([]*model.URL, error) {
-	p, err := newProducer(s.Filename)
-	defer func() {
-		if err = p.Close(); err != nil {
-			log.Println(err)
+// taken reports whether id is used by some url. Caller must hold lock.
+func (s *Store) taken(id string) bool {
+	_, ok := s.index.urls[id]
+	return ok
+}
+
+// ListUserURLs ...
+func (s *Store) ListUserURLs(_ context.Context, user string, opts store.ListOptions) (*store.Page, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// urls are filtered and sorted by fields which are kept in index, so only urls of page are read from file.
+	urls := make([]*model.URL, 0, len(s.index.users[user]))
+	for _, id := range s.index.users[user] {
+		e := s.index.urls[id]
+		if e.deleted {
+			continue
 		}
-	}()
+		urls = append(urls, &model.URL{ID: id, BaseURL: e.url, User: e.user, Tags: e.tags, CreatedAt: e.createdAt})
+	}
+	page, err := store.Paginate(urls, opts)
 	if err != nil {
 		return nil, err
 	}
-	return p.GetAllUserURLs(user)
+	for i, u := range page.URLs {
+		if page.URLs[i], err = s.getURL(u.ID); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
 // Ping ...
 func (s *Store) Ping(_ context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return store.ErrAlreadyClosed
+	}
 	return nil
 }
 
-// URLsBulkCreate ...
-func (s *Store) URLsBulkCreate(_ context.Context, _ []*model.URL) ([]*model.BatchCreateURLsResponse, error) {
-	return nil, nil
+// URLsBulkCreate appends all urls to file with one write. Generated ids which collide with existing ones
+// are regenerated, then whole batch is created again.
+func (s *Store) URLsBulkCreate(ctx context.Context, urls []*model.URL) ([]*model.BatchCreateURLsResponse, error) {
+	if len(urls) == 0 {
+		return nil, store.ErrNoContent
+	}
+
+	err := store.EnsureFreeIDs(ctx, func() ([]*model.URL, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		ids := make(map[string]struct{}, len(urls))
+		taken := func(id string) bool {
+			_, inBatch := ids[id]
+			return inBatch || s.taken(id)
+		}
+
+		var collided []*model.URL
+		records := make([]*record, 0, len(urls))
+		for _, u := range urls {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("context err: %w", err)
+			}
+			if id, e := s.duplicate(u); e != nil {
+				u.ID = id
+				continue
+			}
+			if dup := batchDuplicate(s.dedup, u, records); dup != nil {
+				u.ID = dup.ID
+				continue
+			}
+			free, err := store.FreeID(u, taken)
+			switch {
+			case err != nil:
+				return nil, fmt.Errorf("alias %q: %w", u.Alias, err)
+			case !free:
+				collided = append(collided, u)
+			default:
+				ids[u.ID] = struct{}{}
+				records = append(records, &record{URL: u})
+			}
+		}
+		if len(collided) != 0 {
+			return collided, nil
+		}
+		return nil, s.appendRecords(records...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.BatchCreateURLsResponse, 0, len(urls))
+	for _, u := range urls {
+		res = append(res, &model.BatchCreateURLsResponse{
+			ShortURL:      u.ID,
+			CorrelationID: u.CorelID,
+		})
+	}
+	return res, nil
 }
 
-// URLsBulkDelete ...
-func (s *Store) URLsBulkDelete(_ []string, _ string) error {
+// batchDuplicate returns url of records which is duplicate of u in scope or nil.
+func batchDuplicate(scope store.DedupScope, u *model.URL, records []*record) *model.URL {
+	for _, r := range records {
+		if scope.IsDuplicate(u, r.URL) {
+			return r.URL
+		}
+	}
 	return nil
 }
 
+// URLsBulkDelete appends tombstone of urls which are created by user and are not deleted yet.
+func (s *Store) URLsBulkDelete(ids []string, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted []string
+	for _, id := range ids {
+		if e, ok := s.index.urls[id]; ok && e.user == user && !e.deleted {
+			deleted = append(deleted, id)
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	now := model.Now()
+	return s.appendRecords(&record{Op: opDelete, IDs: deleted, At: &now})
+}
+
+// URLsBulkRestore appends record which restores urls which are created by user and are deleted.
+func (s *Store) URLsBulkRestore(_ context.Context, ids []string, user string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var restored []string
+	for _, id := range ids {
+		if e, ok := s.index.urls[id]; ok && e.user == user && e.deleted {
+			restored = append(restored, id)
+		}
+	}
+	if len(restored) == 0 {
+		return 0, nil
+	}
+	now := model.Now()
+	if err := s.appendRecords(&record{Op: opRestore, IDs: restored, At: &now}); err != nil {
+		return 0, err
+	}
+	return int64(len(restored)), nil
+}
+
+// PurgeDeleted appends record which removes urls deleted before provided time and compacts file, so data
+// of urls is physically removed from disk. Clicks of urls are removed too.
+func (s *Store) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := make(map[string]struct{})
+	var ids []string
+	for id, e := range s.index.urls {
+		if e.deletedBefore(before) {
+			purged[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("context err: %w", err)
+	}
+	if err := s.appendRecords(&record{Op: opPurge, IDs: ids}); err != nil {
+		return 0, err
+	}
+	if err := s.compact(); err != nil {
+		return 0, fmt.Errorf("compact: %w", err)
+	}
+
+	s.clicksMu.Lock()
+	defer s.clicksMu.Unlock()
+	if err := purgeClicks(s.clicksFilename(), purged); err != nil {
+		return 0, fmt.Errorf("purge clicks: %w", err)
+	}
+	return int64(len(ids)), nil
+}
+
+// compact compacts file which is used by store and rebuilds index. Caller must hold write lock.
+func (s *Store) compact() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	compactErr := Compact(s.Filename)
+
+	// file is reopened even if compaction failed, so store is still usable.
+	file, err := os.OpenFile(s.Filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0664)
+	if err != nil {
+		return err
+	}
+	idx, size, err := buildIndex(file)
+	if err != nil {
+		if closeErr := file.Close(); closeErr != nil {
+			log.Println(closeErr)
+		}
+		return fmt.Errorf("build index: %w", err)
+	}
+	s.file, s.index, s.size = file, idx, size
+	return compactErr
+}
+
 // Close ...
 func (s *Store) Close() error {
 	s.mu.Lock()
@@ -104,10 +382,174 @@ func (s *Store) Close() error {
 		return store.ErrAlreadyClosed
 	}
 	s.closed = true
-	return nil
+	return s.file.Close()
 }
 
-// GetData ...
+// GetData returns count of alive urls and count of users which created them.
 func (s *Store) GetData(_ context.Context) (*model.InternalStat, error) {
-	return nil, nil
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stat := new(model.InternalStat)
+	users := make(map[string]struct{})
+	for _, e := range s.index.urls {
+		if e.deleted || e.isExpired() {
+			continue
+		}
+		stat.CountOfURLs++
+		users[e.user] = struct{}{}
+	}
+	stat.CountOfUsers = int64(len(users))
+	return stat, nil
+}
+
+// SweepExpired appends record which marks all expired urls as swept.
+func (s *Store) SweepExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []string
+	for id, e := range s.index.urls {
+		if !e.swept && e.isExpired() {
+			expired = append(expired, id)
+		}
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	now := model.Now()
+	if err := s.appendRecords(&record{Op: opSweep, IDs: expired, At: &now}); err != nil {
+		return 0, err
+	}
+	return int64(len(expired)), nil
+}
+
+// clicksFilename returns name of file where clicks are stored.
+func (s *Store) clicksFilename() string {
+	return s.Filename + ".clicks"
+}
+
+// SaveClicks ...
+func (s *Store) SaveClicks(_ context.Context, clicks []*model.Click) error {
+	s.clicksMu.Lock()
+	defer s.clicksMu.Unlock()
+
+	p, err := newProducer(s.clicksFilename())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = p.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	for _, c := range clicks {
+		if err = p.SaveClick(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLinkStats ...
+func (s *Store) GetLinkStats(_ context.Context, id string) (*model.LinkStats, error) {
+	s.mu.RLock()
+	e, ok := s.index.urls[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	s.clicksMu.Lock()
+	defer s.clicksMu.Unlock()
+
+	p, err := newProducer(s.clicksFilename())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = p.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	c, err := p.GetClicks(id)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewLinkStats(id, e.user, c), nil
+}
+
+// UpdateURL writes changed url with its history as new record of url, which replaces previous one in index.
+func (s *Store) UpdateURL(_ context.Context, id string, opts ...model.URLOption) (*model.URL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.getURL(id)
+	switch {
+	case err != nil:
+		return nil, err
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	}
+	prev := u.BaseURL
+	if err = u.Apply(opts...); err != nil {
+		return nil, err
+	}
+	u.UpdatedAt = model.Now()
+	history := s.index.urls[id].history
+	if u.BaseURL != prev {
+		if dupID, _ := s.duplicate(u); dupID != "" {
+			return nil, store.ErrAlreadyExists
+		}
+		// history of entry is not changed until record is written.
+		history = append(history[:len(history):len(history)], &model.Destination{OriginalURL: prev, ReplacedAt: u.UpdatedAt})
+	}
+	if err = s.appendRecords(&record{URL: u, Swept: u.IsSwept, History: history}); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// TakeClick ...
+func (s *Store) TakeClick(_ context.Context, id string) (*model.URL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.getURL(id)
+	switch {
+	case err != nil:
+		return nil, err
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	case u.IsExpired():
+		return nil, store.ErrExpired
+	case !u.IsLimited():
+		return u, nil
+	case u.IsExhausted():
+		return nil, store.ErrExhausted
+	}
+	if err = s.appendRecords(&record{Op: opClick, IDs: []string{id}}); err != nil {
+		return nil, err
+	}
+	u.ClicksLeft--
+	return u, nil
+}
+
+// GetHistory ...
+func (s *Store) GetHistory(_ context.Context, id string) ([]*model.Destination, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.index.urls[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return append([]*model.Destination(nil), e.history...), nil
+}
+
+// NextSequence ...
+func (s *Store) NextSequence(_ context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	return s.seq, nil
 }
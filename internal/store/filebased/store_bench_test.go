@@ -0,0 +1,77 @@
+package filebased
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// benchStore returns store with count urls in it and ids of created urls.
+func benchStore(b *testing.B, count int) (*Store, []string) {
+	b.Helper()
+	s, err := New(filepath.Join(b.TempDir(), "file"))
+	require.NoError(b, err)
+
+	urls := make([]*model.URL, 0, count)
+	for i := 0; i < count; i++ {
+		u, err := model.NewURL(fmt.Sprintf("https://example.org/%d", i), fmt.Sprintf("user-%d", i%10))
+		require.NoError(b, err)
+		urls = append(urls, u)
+	}
+	_, err = s.URLsBulkCreate(context.Background(), urls)
+	require.NoError(b, err)
+
+	ids := make([]string, 0, count)
+	for _, u := range urls {
+		ids = append(ids, u.ID)
+	}
+	return s, ids
+}
+
+func BenchmarkStore_GetByID(b *testing.B) {
+	for _, count := range []int{100, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("%d urls", count), func(b *testing.B) {
+			s, ids := benchStore(b, count)
+			defer func() {
+				require.NoError(b, s.Close())
+			}()
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.GetByID(ctx, ids[i%len(ids)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStore_Create(b *testing.B) {
+	for _, count := range []int{100, 100_000} {
+		b.Run(fmt.Sprintf("%d urls", count), func(b *testing.B) {
+			s, _ := benchStore(b, count)
+			defer func() {
+				require.NoError(b, s.Close())
+			}()
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				u, err := model.NewURL("https://example.org", "marlo")
+				require.NoError(b, err)
+				b.StartTimer()
+
+				if err = s.Create(ctx, u); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
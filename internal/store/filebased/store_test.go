@@ -1,12 +1,19 @@
 package filebased
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/vlad-marlo/shortener/internal/idgen"
+	"github.com/vlad-marlo/shortener/internal/store"
+	"github.com/vlad-marlo/shortener/internal/store/model"
 )
 
 func TestStore_Ping(t *testing.T) {
@@ -32,3 +39,681 @@ func TestStore_Close(t *testing.T) {
 	err = store.Close()
 	require.NoError(t, err, fmt.Sprintf("ping: %v", err))
 }
+
+func TestStore_GetLinkStats(t *testing.T) {
+	filename := "file"
+	defer func() {
+		_ = os.Remove(filename)
+		_ = os.Remove(filename + ".clicks")
+	}()
+
+	s, err := New(filename)
+	require.NoError(t, err)
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(context.Background(), u))
+
+	_, err = s.GetLinkStats(context.Background(), "unknown")
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	require.NoError(t, s.SaveClicks(context.Background(), []*model.Click{
+		{URLID: u.ID, Time: time.Now()},
+		{URLID: "another", Time: time.Now()},
+	}))
+	stats, err := s.GetLinkStats(context.Background(), u.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Total)
+	require.Equal(t, "marlo", stats.User)
+}
+
+func TestStore_BulkCreateAndDelete(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = s.URLsBulkCreate(ctx, nil)
+	require.ErrorIs(t, err, store.ErrNoContent)
+
+	var urls []*model.URL
+	for i, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo", fmt.Sprint(i))
+		require.NoError(t, err)
+		urls = append(urls, u)
+	}
+	resp, err := s.URLsBulkCreate(ctx, urls)
+	require.NoError(t, err)
+	require.Len(t, resp, len(urls))
+	for i, r := range resp {
+		require.Equal(t, urls[i].ID, r.ShortURL)
+		require.Equal(t, fmt.Sprint(i), r.CorrelationID)
+	}
+
+	require.NoError(t, s.URLsBulkDelete([]string{urls[0].ID, urls[1].ID}, "another"))
+	require.NoError(t, s.URLsBulkDelete([]string{urls[0].ID}, "marlo"))
+
+	_, err = s.GetByID(ctx, urls[0].ID)
+	require.ErrorIs(t, err, store.ErrIsDeleted)
+	u, err := s.GetByID(ctx, urls[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, urls[1].BaseURL, u.BaseURL)
+
+	page, err := s.ListUserURLs(ctx, "marlo", store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 2)
+
+	stat, err := s.GetData(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &model.InternalStat{CountOfURLs: 2, CountOfUsers: 1}, stat)
+}
+
+func TestCompact(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	u1, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	u2, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u1))
+	require.NoError(t, s.Create(ctx, u2))
+	require.NoError(t, s.URLsBulkDelete([]string{u1.ID}, "marlo"))
+	require.NoError(t, s.Close())
+
+	before, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, 3, bytes.Count(before, []byte("\n")))
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	reopened, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, before, reopened, "file with few stale records must not be compacted on open")
+	require.NoError(t, s.Close())
+
+	require.NoError(t, Compact(filename))
+	after, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, 2, bytes.Count(after, []byte("\n")), "tombstone must be folded into url record")
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	_, err = s.GetByID(ctx, u1.ID)
+	require.ErrorIs(t, err, store.ErrIsDeleted)
+	_, err = s.GetByID(ctx, u2.ID)
+	require.NoError(t, err)
+}
+
+func TestCompact_Threshold(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	for i := 0; i < compactStaleRecords/2; i++ {
+		require.NoError(t, s.URLsBulkDelete([]string{u.ID}, "marlo"))
+		_, err = s.URLsBulkRestore(ctx, []string{u.ID}, "marlo")
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.Close())
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, 1, bytes.Count(data, []byte("\n")), "file with many stale records must be compacted on open")
+	_, err = s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+}
+
+func TestStore_DedupScope(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	s.SetDedupScope(store.DedupUser)
+
+	first, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, first))
+
+	dup, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	require.Equal(t, first.ID, dup.ID)
+
+	// other user gets own url, both in batch and in single creation
+	var batch []*model.URL
+	for i := 0; i < 2; i++ {
+		u, err := model.NewURL("https://example.org", "b", fmt.Sprint(i))
+		require.NoError(t, err)
+		batch = append(batch, u)
+	}
+	resp, err := s.URLsBulkCreate(ctx, batch)
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	require.NotEqual(t, first.ID, resp[0].ShortURL)
+	require.Equal(t, resp[0].ShortURL, resp[1].ShortURL)
+
+	page, err := s.ListUserURLs(ctx, "b", store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+}
+
+func TestStore_RestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	var urls []*model.URL
+	for _, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, s.Create(ctx, u))
+		urls = append(urls, u)
+	}
+	require.NoError(t, s.URLsBulkDelete([]string{urls[0].ID, urls[1].ID}, "marlo"))
+	require.NoError(t, s.SaveClicks(ctx, []*model.Click{
+		{URLID: urls[1].ID, Time: time.Now()},
+		{URLID: urls[2].ID, Time: time.Now()},
+	}))
+
+	n, err := s.URLsBulkRestore(ctx, []string{urls[0].ID}, "another")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+	n, err = s.URLsBulkRestore(ctx, []string{urls[0].ID}, "marlo")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = s.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+	n, err = s.PurgeDeleted(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// purged url is physically removed from file, other data is kept
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), urls[1].ID)
+	clicks, err := os.ReadFile(filename + ".clicks")
+	require.NoError(t, err)
+	require.NotContains(t, string(clicks), urls[1].ID)
+	require.Contains(t, string(clicks), urls[2].ID)
+
+	// store is usable after purge and state survives reopening
+	u, err := model.NewURL("https://example.io", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	require.NoError(t, s.Close())
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	for _, id := range []string{urls[0].ID, urls[2].ID, u.ID} {
+		_, err = s.GetByID(ctx, id)
+		require.NoError(t, err)
+	}
+	_, err = s.GetByID(ctx, urls[1].ID)
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestStore_ListUserURLs(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "file"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+
+	var urls []*model.URL
+	for _, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, s.Create(ctx, u))
+		urls = append(urls, u)
+	}
+	other, err := model.NewURL("https://example.io", "another")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, other))
+	require.NoError(t, s.URLsBulkDelete([]string{urls[1].ID}, "marlo"))
+
+	opts := store.ListOptions{Limit: 1, Sort: store.SortURLDesc}
+	page, err := s.ListUserURLs(ctx, "marlo", opts)
+	require.NoError(t, err)
+	require.Equal(t, []*model.URL{urls[0]}, page.URLs)
+	require.NotEmpty(t, page.NextCursor)
+
+	opts.Cursor = page.NextCursor
+	page, err = s.ListUserURLs(ctx, "marlo", opts)
+	require.NoError(t, err)
+	require.Equal(t, []*model.URL{urls[2]}, page.URLs)
+	require.Empty(t, page.NextCursor)
+
+	_, err = s.ListUserURLs(ctx, "marlo", store.ListOptions{Sort: "id"})
+	require.ErrorIs(t, err, store.ErrBadListOptions)
+}
+
+func TestStore_Timestamps(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.Equal(t, u.CreatedAt, u.UpdatedAt)
+	u.CreatedAt = u.CreatedAt.Add(-time.Hour)
+	u.UpdatedAt = u.CreatedAt
+	require.NoError(t, s.Create(ctx, u))
+	require.NoError(t, s.URLsBulkDelete([]string{u.ID}, "marlo"))
+	_, err = s.URLsBulkRestore(ctx, []string{u.ID}, "marlo")
+	require.NoError(t, err)
+
+	got, err := s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, u.CreatedAt, got.CreatedAt)
+	require.True(t, got.UpdatedAt.After(u.CreatedAt), "restore must update modification time")
+	require.Nil(t, got.DeletedAt)
+	require.NoError(t, s.Close())
+
+	// times survive compaction of file
+	require.NoError(t, Compact(filename))
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	reopened, err := s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, got.CreatedAt, reopened.CreatedAt)
+	require.Equal(t, got.UpdatedAt, reopened.UpdatedAt)
+}
+
+func TestStore_Metadata(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	tagged, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, tagged.Apply(model.WithMetadata("Example", "some note", []string{"News", "ads"})))
+	require.NoError(t, s.Create(ctx, tagged))
+	other, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, other))
+	require.NoError(t, s.Close())
+
+	// metadata is kept in file and index is rebuilt from it
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	page, err := s.ListUserURLs(ctx, "marlo", store.ListOptions{Tag: "NEWS"})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	require.Equal(t, tagged.ID, page.URLs[0].ID)
+	require.Equal(t, "Example", page.URLs[0].Title)
+	require.Equal(t, "some note", page.URLs[0].Note)
+	require.Equal(t, []string{"ads", "news"}, page.URLs[0].Tags)
+}
+
+func TestStore_UpdateURL_Destination(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	other, err := model.NewURL("https://example.net", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, other))
+
+	_, err = s.UpdateURL(ctx, u.ID, model.WithDestination(other.BaseURL, ""))
+	require.ErrorIs(t, err, store.ErrAlreadyExists)
+	updated, err := s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.com", ""))
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com", updated.BaseURL)
+
+	// new destination is deduplicated, previous one is free
+	dup, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	require.Equal(t, u.ID, dup.ID)
+	again, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, again))
+
+	check := func(s *Store) {
+		got, err := s.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", got.BaseURL)
+		require.Equal(t, updated.UpdatedAt, got.UpdatedAt)
+		history, err := s.GetHistory(ctx, u.ID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.Destination{{OriginalURL: "https://example.org", ReplacedAt: updated.UpdatedAt}}, history)
+	}
+	check(s)
+	require.NoError(t, s.Close())
+
+	// history survives compaction which is done on opening of file
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	check(s)
+	_, err = s.GetHistory(ctx, "unknown")
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestStore_RawURL(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	const raw = "HTTPS://Example.org:443/?b=1&a=2"
+	u, err := model.NewURL(raw, "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithCanonicalURL(false)))
+	require.NoError(t, s.Create(ctx, u))
+
+	// urls are deduplicated by canonical forms
+	dup, err := model.NewURL("https://example.org/?a=2&b=1", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, dup.Apply(model.WithCanonicalURL(false)))
+	require.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	require.Equal(t, u.ID, dup.ID)
+
+	require.NoError(t, s.Close())
+	s, err = New(filename)
+	require.NoError(t, err)
+	got, err := s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.org/?a=2&b=1", got.BaseURL)
+	require.Equal(t, raw, got.RawURL)
+
+	// raw url belongs to previous destination only
+	updated, err := s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.com/", ""))
+	require.NoError(t, err)
+	require.Empty(t, updated.RawURL)
+	require.NoError(t, s.Close())
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	got, err = s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/", got.BaseURL)
+	require.Empty(t, got.RawURL)
+}
+
+func TestStore_UpdateURL_Settings(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	_, err = s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.com", ""))
+	require.NoError(t, err)
+	// url isn't changed partially if any option is bad.
+	_, err = s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.net", ""), model.WithRedirectCode(200))
+	require.ErrorIs(t, err, model.ErrRedirectCodeBad)
+	rules := []*model.Rule{{Device: model.DeviceIOS, Destination: "https://apps.apple.com"}}
+	updated, err := s.UpdateURL(ctx, u.ID, model.WithRedirectCode(308), model.WithPassword("secret"), model.WithRules(rules))
+	require.NoError(t, err)
+	require.Equal(t, 308, updated.Redirect())
+	require.True(t, updated.CheckPassword("secret"))
+	require.Equal(t, "https://example.com", updated.BaseURL)
+
+	check := func(s *Store) {
+		got, err := s.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+		history, err := s.GetHistory(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		page, err := s.ListUserURLs(ctx, "marlo", store.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, page.URLs, 1)
+	}
+	check(s)
+	require.NoError(t, s.Close())
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	check(s)
+}
+
+func TestStore_TakeClick(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(model.WithMaxClicks(2)))
+	require.NoError(t, s.Create(ctx, u))
+	got, err := s.TakeClick(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got.ClicksLeft)
+	require.NoError(t, s.Close())
+
+	// remaining clicks survive compaction and settings changes.
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	got, err = s.UpdateURL(ctx, u.ID, model.WithRedirectCode(301))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got.ClicksLeft)
+	got, err = s.TakeClick(ctx, u.ID)
+	require.NoError(t, err)
+	require.True(t, got.IsExhausted())
+	_, err = s.TakeClick(ctx, u.ID)
+	require.ErrorIs(t, err, store.ErrExhausted)
+	got, err = s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), got.ClicksLeft)
+}
+
+func TestStore_Create_Protected(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+	plain, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, plain))
+
+	protected, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, protected.Apply(model.WithPassword("secret")))
+	require.NoError(t, s.Create(ctx, protected))
+	require.NotEqual(t, plain.ID, protected.ID)
+
+	_, err = s.UpdateURL(ctx, plain.ID, model.WithPassword("secret"))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// protection of urls is kept in index which is built from file.
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	u, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	require.NotEqual(t, plain.ID, u.ID)
+	require.NotEqual(t, protected.ID, u.ID)
+}
+
+func TestStore_Create_SequentialCollision(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "file"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	gen, err := idgen.NewSequential(s)
+	require.NoError(t, err)
+	// next value of counter is encoded as "100".
+	s.seq = 62*62 - 1
+	for _, alias := range []string{"100", "103"} {
+		u, err := model.NewURL("https://example.org/"+alias, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, u.Apply(model.WithAlias(alias)))
+		require.NoError(t, s.Create(ctx, u))
+	}
+
+	// generator takes next value of counter from store, so it must be called without lock of store.
+	u, err := model.NewURLWithGenerator(ctx, gen, "https://example.com", "marlo")
+	require.NoError(t, err)
+	require.Equal(t, "100", u.ID)
+	require.NoError(t, s.Create(ctx, u))
+	require.Equal(t, "101", u.ID)
+
+	var batch []*model.URL
+	for _, base := range []string{"https://example.net", "https://example.info"} {
+		u, err := model.NewURLWithGenerator(ctx, gen, base, "marlo")
+		require.NoError(t, err)
+		batch = append(batch, u)
+	}
+	res, err := s.URLsBulkCreate(ctx, batch)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "102", res[0].ShortURL)
+	require.Equal(t, "104", res[1].ShortURL)
+}
+
+func TestStore_NextSequence(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	for want := uint64(1); want <= 3; want++ {
+		got, err := s.NextSequence(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	require.NoError(t, s.Close())
+
+	// counter is restored from file, even if url which was created with it is purged.
+	s, err = New(filename)
+	require.NoError(t, err)
+	require.NoError(t, s.URLsBulkDelete([]string{u.ID}, "marlo"))
+	purged, err := s.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+	require.NoError(t, s.Close())
+
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	got, err := s.NextSequence(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), got)
+}
+
+func TestStore_Create_Deleted(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "file"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	deleted, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, deleted))
+	require.NoError(t, s.URLsBulkDelete([]string{deleted.ID}, "a"))
+
+	// deleted url isn't duplicate, so url is created anew.
+	u, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	require.NotEqual(t, deleted.ID, u.ID)
+
+	dup, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	require.Equal(t, u.ID, dup.ID)
+}
+
+func TestStore_SweepExpired(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "file")
+	s, err := New(filename)
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	expired, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	expired.ExpiresAt = &past
+	require.NoError(t, s.Create(ctx, expired))
+	alive, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, alive))
+
+	n, err := s.SweepExpired(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.NoError(t, s.Close())
+
+	// sweeping mark is kept in file and swept url isn't deleted.
+	s, err = New(filename)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	n, err = s.SweepExpired(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	_, err = s.GetByID(ctx, expired.ID)
+	require.ErrorIs(t, err, store.ErrExpired)
+	restored, err := s.URLsBulkRestore(ctx, []string{expired.ID}, "marlo")
+	require.NoError(t, err)
+	require.Zero(t, restored)
+	purged, err := s.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purged)
+	_, err = s.GetByID(ctx, expired.ID)
+	require.ErrorIs(t, err, store.ErrExpired)
+}
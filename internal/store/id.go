@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// EnsureFreeIDs calls create, which creates urls under lock of store and returns urls which ids are not
+// free, until all urls are created. Ids of returned urls are regenerated between calls without lock, so
+// id generators which use store as counter don't deadlock. After MaxIDAttempts regenerations
+// ErrIDCollision is returned.
+func EnsureFreeIDs(ctx context.Context, create func() ([]*model.URL, error)) error {
+	for attempt := 0; ; attempt++ {
+		taken, err := create()
+		if err != nil || len(taken) == 0 {
+			return err
+		}
+		if attempt == MaxIDAttempts {
+			return ErrIDCollision
+		}
+		if err = ctx.Err(); err != nil {
+			return fmt.Errorf("context err: %w", err)
+		}
+		for _, u := range taken {
+			if err = u.ShortURL(ctx); err != nil {
+				return fmt.Errorf("short url: %w", err)
+			}
+		}
+	}
+}
+
+// FreeID reports whether id of url isn't used by some url and isn't reserved. Id of url with alias is
+// never regenerated, so ErrAliasTaken is returned if alias is used.
+func FreeID(u *model.URL, taken func(id string) bool) (bool, error) {
+	switch {
+	case u.HasAlias() && taken(u.ID):
+		return false, ErrAliasTaken
+	case u.HasAlias():
+		return true, nil
+	default:
+		return !taken(u.ID) && !model.IsReservedID(u.ID), nil
+	}
+}
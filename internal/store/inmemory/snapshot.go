@@ -0,0 +1,166 @@
+package inmemory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// snapshotURL is url which is written to snapshot. Deletion and sweeping marks are not encoded by model.URL
+// itself, so they are stored separately.
+type snapshotURL struct {
+	*model.URL
+	Deleted   bool                 `json:"deleted,omitempty"`
+	DeletedAt *time.Time           `json:"deleted_at,omitempty"`
+	Swept     bool                 `json:"swept,omitempty"`
+	History   []*model.Destination `json:"history,omitempty"`
+}
+
+// snapshot is content of snapshot file.
+type snapshot struct {
+	URLs []*snapshotURL `json:"urls"`
+	Seq  uint64         `json:"seq,omitempty"`
+}
+
+// NewWithSnapshot returns store which is restored from snapshot file if it exists. Store writes snapshot
+// every interval and on Close. If interval is not positive, snapshot is written only on Close.
+func NewWithSnapshot(filename string, interval time.Duration) (*Store, error) {
+	if filename == "" {
+		return nil, errors.New("snapshot file name must be provided")
+	}
+	s := New()
+	s.snapshotFile = filename
+	if err := s.restore(); err != nil {
+		return nil, fmt.Errorf("restore: %w", err)
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.snapshotLoop(interval)
+	return s, nil
+}
+
+// restore loads urls from snapshot file. Missing file is not an error: store is just empty.
+func (s *Store) restore() error {
+	f, err := os.Open(s.snapshotFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	var snap snapshot
+	if err = json.NewDecoder(bufio.NewReader(f)).Decode(&snap); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq = snap.Seq
+	for _, u := range snap.URLs {
+		if u.URL == nil {
+			continue
+		}
+		u.URL.IsDeleted = u.Deleted
+		u.URL.DeletedAt = u.DeletedAt
+		u.URL.IsSwept = u.Swept
+		s.add(u.URL)
+		if len(u.History) > 0 {
+			s.history[u.URL.ID] = u.History
+		}
+	}
+	return nil
+}
+
+// snapshotLoop writes snapshot every interval until store is closed.
+func (s *Store) snapshotLoop(interval time.Duration) {
+	defer close(s.done)
+	if interval <= 0 {
+		<-s.stop
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.Snapshot(); err != nil {
+				log.Printf("inmemory: snapshot: %v", err)
+			}
+		}
+	}
+}
+
+// Snapshot writes all urls to snapshot file. File is replaced atomically with rename of temporary file,
+// so crash during writing never corrupts previous snapshot.
+func (s *Store) Snapshot() error {
+	if s.snapshotFile == "" {
+		return nil
+	}
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	s.mu.Lock()
+	snap := snapshot{
+		URLs: make([]*snapshotURL, 0, len(s.urls)),
+		Seq:  s.seq,
+	}
+	for _, u := range s.urls {
+		// copy of url is encoded because url may be changed by store after lock is released.
+		c := *u
+		snap.URLs = append(snap.URLs, &snapshotURL{
+			URL:       &c,
+			Deleted:   c.IsDeleted,
+			DeletedAt: c.DeletedAt,
+			Swept:     c.IsSwept,
+			History:   s.history[u.ID],
+		})
+	}
+	s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.snapshotFile), filepath.Base(s.snapshotFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	w := bufio.NewWriter(tmp)
+	if err = json.NewEncoder(w).Encode(&snap); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(0664); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.snapshotFile)
+}
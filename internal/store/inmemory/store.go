@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
@@ -14,15 +15,64 @@ type Store struct {
 	mu     sync.Mutex
 	closed bool
 
-	urls map[string]*model.URL
+	urls    map[string]*model.URL
+	clicks  map[string][]*model.Click
+	history map[string][]*model.Destination
+	seq     uint64
+
+	// byURL maps original urls to ids of urls which are shortening them.
+	byURL map[string][]string
+	// users maps users to ids of urls which they created.
+	users map[string][]string
+	dedup store.DedupScope
+
+	snapshotFile string
+	snapshotMu   sync.Mutex
+	stop         chan struct{}
+	done         chan struct{}
 }
 
 // New ...
 func New() *Store {
 	return &Store{
-		urls:   make(map[string]*model.URL),
-		closed: false,
+		urls:    make(map[string]*model.URL),
+		clicks:  make(map[string][]*model.Click),
+		history: make(map[string][]*model.Destination),
+		byURL:   make(map[string][]string),
+		users:   make(map[string][]string),
+		closed:  false,
+	}
+}
+
+// SetDedupScope ...
+func (s *Store) SetDedupScope(scope store.DedupScope) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedup = scope
+}
+
+// add stores url. Caller must hold lock.
+func (s *Store) add(u *model.URL) {
+	if s.byURL == nil {
+		s.byURL = make(map[string][]string)
+	}
+	if s.users == nil {
+		s.users = make(map[string][]string)
+	}
+	s.urls[u.ID] = u
+	s.byURL[u.BaseURL] = append(s.byURL[u.BaseURL], u.ID)
+	s.users[u.User] = append(s.users[u.User], u.ID)
+}
+
+// duplicate returns url which is duplicate of u in dedup scope of store or nil. Deleted urls have no
+// duplicates, so url is created anew instead of deleted one. Caller must hold lock.
+func (s *Store) duplicate(u *model.URL) *model.URL {
+	for _, id := range s.byURL[u.BaseURL] {
+		if existing := s.urls[id]; !existing.IsDeleted && s.dedup.IsDuplicate(u, existing) {
+			return existing
+		}
 	}
+	return nil
 }
 
 // GetByID returns URL object and error by URL ID
@@ -36,6 +86,8 @@ func (s *Store) GetByID(_ context.Context, id string) (u *model.URL, err error)
 		return nil, store.ErrNotFound
 	case u.IsDeleted:
 		return nil, store.ErrIsDeleted
+	case u.IsExpired():
+		return nil, store.ErrExpired
 	default:
 		return
 	}
@@ -43,56 +95,76 @@ func (s *Store) GetByID(_ context.Context, id string) (u *model.URL, err error)
 
 // Create URL model to storage
 func (s *Store) Create(ctx context.Context, u *model.URL) (err error) {
-	if err = u.Validate(); err != nil {
+	if err = model.CheckFormat(u.BaseURL); err != nil {
 		return fmt.Errorf("validate url: %w", err)
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	for _, ok := s.urls[u.ID]; ok; _, ok = s.urls[u.ID] {
-		if err = ctx.Err(); err != nil {
-			return fmt.Errorf("context err: %w", err)
+	return store.EnsureFreeIDs(ctx, func() ([]*model.URL, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if existing := s.duplicate(u); existing != nil {
+			u.ID = existing.ID
+			return nil, store.ErrAlreadyExists
 		}
-
-		if err = u.ShortURL(); err != nil {
-			return fmt.Errorf("short url: %w", err)
+		free, err := store.FreeID(u, s.taken)
+		switch {
+		case err != nil:
+			return nil, err
+		case !free:
+			return []*model.URL{u}, nil
 		}
-	}
-
-	s.urls[u.ID] = u
-	return
+		s.add(u)
+		return nil, nil
+	})
 }
 
-// GetAllUserURLs ...
-func (s *Store) GetAllUserURLs(_ context.Context, user string) (urls []*model.URL, err error) {
+// ListUserURLs ...
+func (s *Store) ListUserURLs(ctx context.Context, user string, opts store.ListOptions) (*store.Page, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	for _, u := range s.urls {
-		if u.User == user {
+	urls := make([]*model.URL, 0, len(s.users[user]))
+	for _, id := range s.users[user] {
+		if u := s.urls[id]; !u.IsDeleted {
 			urls = append(urls, u)
 		}
 	}
-	return
+	s.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context err: %w", err)
+	}
+	return store.Paginate(urls, opts)
 }
 
 // URLsBulkCreate ...
 func (s *Store) URLsBulkCreate(ctx context.Context, urls []*model.URL) (res []*model.BatchCreateURLsResponse, err error) {
-	for _, u := range urls {
-		if err := ctx.Err(); err != nil {
-			return nil, fmt.Errorf("context err: %w", err)
-		}
-
-		if err := u.ShortURL(); err != nil {
-			return nil, fmt.Errorf("short url: %w", err)
-		}
-
+	// urls which ids are taken are created again after their ids are regenerated.
+	pending := urls
+	err = store.EnsureFreeIDs(ctx, func() ([]*model.URL, error) {
 		s.mu.Lock()
-		if _, ok := s.urls[u.ID]; ok {
-			return nil, fmt.Errorf("already exist: %w", store.ErrAlreadyExists)
+		defer s.mu.Unlock()
+		var taken []*model.URL
+		for _, u := range pending {
+			if existing := s.duplicate(u); existing != nil {
+				u.ID = existing.ID
+				continue
+			}
+			free, err := store.FreeID(u, s.taken)
+			switch {
+			case err != nil:
+				return nil, fmt.Errorf("alias %q: %w", u.Alias, err)
+			case !free:
+				taken = append(taken, u)
+			default:
+				s.add(u)
+			}
 		}
-		s.urls[u.ID] = u
-		s.mu.Unlock()
+		pending = taken
+		return taken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	for _, u := range urls {
 		res = append(res, &model.BatchCreateURLsResponse{
 			ShortURL:      u.ID,
 			CorrelationID: u.CorelID,
@@ -101,18 +173,85 @@ func (s *Store) URLsBulkCreate(ctx context.Context, urls []*model.URL) (res []*m
 	return res, nil
 }
 
+// taken reports whether id is used by some url. Caller must hold lock.
+func (s *Store) taken(id string) bool {
+	_, ok := s.urls[id]
+	return ok
+}
+
 // URLsBulkDelete ...
 func (s *Store) URLsBulkDelete(urls []string, user string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	for _, u := range urls {
-		if url := s.urls[u]; url.User == user {
-			url.IsDeleted = true
+	now := model.Now()
+	for _, id := range urls {
+		if u, ok := s.urls[id]; ok && u.User == user && !u.IsDeleted {
+			// url is replaced with changed copy, so urls which were returned before are not changed.
+			changed := *u
+			changed.MarkDeleted(now)
+			s.urls[id] = &changed
 		}
 	}
 	return nil
 }
 
+// URLsBulkRestore ...
+func (s *Store) URLsBulkRestore(_ context.Context, ids []string, user string) (n int64, err error) {
+	now := model.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if u, ok := s.urls[id]; ok && u.User == user && u.IsDeleted {
+			changed := *u
+			changed.Restore(now)
+			s.urls[id] = &changed
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PurgeDeleted ...
+func (s *Store) PurgeDeleted(ctx context.Context, before time.Time) (n int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, u := range s.urls {
+		if err = ctx.Err(); err != nil {
+			return n, fmt.Errorf("context err: %w", err)
+		}
+		if !u.DeletedBefore(before) {
+			continue
+		}
+		delete(s.urls, id)
+		delete(s.clicks, id)
+		delete(s.history, id)
+		s.unindexURL(u.BaseURL, id)
+		if s.users[u.User] = removeID(s.users[u.User], id); len(s.users[u.User]) == 0 {
+			delete(s.users, u.User)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// unindexURL removes id from ids of urls which are shortening original url. Caller must hold lock.
+func (s *Store) unindexURL(url, id string) {
+	if s.byURL[url] = removeID(s.byURL[url], id); len(s.byURL[url]) == 0 {
+		delete(s.byURL, url)
+	}
+}
+
+// removeID returns ids without id.
+func removeID(ids []string, id string) []string {
+	res := ids[:0]
+	for _, i := range ids {
+		if i != id {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
 // Ping returns always
 func (s *Store) Ping(_ context.Context) error {
 	s.mu.Lock()
@@ -124,7 +263,7 @@ func (s *Store) Ping(_ context.Context) error {
 	return nil
 }
 
-// Close ...
+// Close stops snapshotting and writes last snapshot if store was created with it.
 func (s *Store) Close() error {
 	s.mu.Lock()
 
@@ -136,10 +275,144 @@ func (s *Store) Close() error {
 	if ok {
 		return store.ErrAlreadyClosed
 	}
-	return nil
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+	return s.Snapshot()
 }
 
-// GetData ...
+// GetData returns count of alive urls and count of users which created them.
 func (s *Store) GetData(_ context.Context) (*model.InternalStat, error) {
-	return nil, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat := new(model.InternalStat)
+	users := make(map[string]struct{})
+	for _, u := range s.urls {
+		if u.IsDeleted || u.IsExpired() {
+			continue
+		}
+		stat.CountOfURLs++
+		users[u.User] = struct{}{}
+	}
+	stat.CountOfUsers = int64(len(users))
+	return stat, nil
+}
+
+// SweepExpired ...
+func (s *Store) SweepExpired(ctx context.Context) (n int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, u := range s.urls {
+		if err = ctx.Err(); err != nil {
+			return n, fmt.Errorf("context err: %w", err)
+		}
+		if !u.IsSwept && u.IsExpired() {
+			changed := *u
+			changed.MarkSwept(model.Now())
+			s.urls[id] = &changed
+			n++
+		}
+	}
+	return n, nil
+}
+
+// SaveClicks ...
+func (s *Store) SaveClicks(_ context.Context, clicks []*model.Click) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clicks == nil {
+		s.clicks = make(map[string][]*model.Click)
+	}
+	for _, c := range clicks {
+		s.clicks[c.URLID] = append(s.clicks[c.URLID], c)
+	}
+	return nil
+}
+
+// GetLinkStats ...
+func (s *Store) GetLinkStats(_ context.Context, id string) (*model.LinkStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.urls[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return model.NewLinkStats(id, u.User, s.clicks[id]), nil
+}
+
+// UpdateURL ...
+func (s *Store) UpdateURL(_ context.Context, id string, opts ...model.URLOption) (*model.URL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.urls[id]
+	switch {
+	case !ok:
+		return nil, store.ErrNotFound
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	}
+
+	// url is replaced with changed copy, so urls which were returned before are not changed.
+	changed := *u
+	if err := changed.Apply(opts...); err != nil {
+		return nil, err
+	}
+	changed.UpdatedAt = model.Now()
+	if changed.BaseURL != u.BaseURL {
+		if s.duplicate(&changed) != nil {
+			return nil, store.ErrAlreadyExists
+		}
+		s.unindexURL(u.BaseURL, id)
+		s.byURL[changed.BaseURL] = append(s.byURL[changed.BaseURL], id)
+		if s.history == nil {
+			s.history = make(map[string][]*model.Destination)
+		}
+		s.history[id] = append(s.history[id], &model.Destination{OriginalURL: u.BaseURL, ReplacedAt: changed.UpdatedAt})
+	}
+	s.urls[id] = &changed
+	return &changed, nil
+}
+
+// TakeClick ...
+func (s *Store) TakeClick(_ context.Context, id string) (*model.URL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.urls[id]
+	switch {
+	case !ok:
+		return nil, store.ErrNotFound
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	case u.IsExpired():
+		return nil, store.ErrExpired
+	case !u.IsLimited():
+		return u, nil
+	case u.IsExhausted():
+		return nil, store.ErrExhausted
+	}
+
+	changed := *u
+	changed.ClicksLeft--
+	s.urls[id] = &changed
+	return &changed, nil
+}
+
+// GetHistory ...
+func (s *Store) GetHistory(_ context.Context, id string) ([]*model.Destination, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.urls[id]; !ok {
+		return nil, store.ErrNotFound
+	}
+	return append([]*model.Destination(nil), s.history[id]...), nil
+}
+
+// NextSequence ...
+func (s *Store) NextSequence(_ context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	return s.seq, nil
 }
@@ -2,11 +2,18 @@ package inmemory
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/vlad-marlo/shortener/internal/idgen"
 	"github.com/vlad-marlo/shortener/internal/store"
 	"github.com/vlad-marlo/shortener/internal/store/model"
 )
@@ -126,3 +133,483 @@ func TestStore_Close(t *testing.T) {
 	require.NoError(t, s.Close())
 	require.Error(t, s.Close())
 }
+
+func TestStore_Create_Alias(t *testing.T) {
+	s := New()
+	u1, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u1.Apply(model.WithAlias("example")))
+	require.NoError(t, s.Create(context.Background(), u1))
+
+	u2, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u2.Apply(model.WithAlias("example")))
+	assert.ErrorIs(t, s.Create(context.Background(), u2), store.ErrAliasTaken)
+
+	got, err := s.GetByID(context.Background(), "example")
+	require.NoError(t, err)
+	assert.Equal(t, u1.BaseURL, got.BaseURL)
+}
+
+func TestStore_SweepExpired(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	s := &Store{
+		urls: map[string]*model.URL{
+			"expired": {ID: "expired", BaseURL: "https://example.org", ExpiresAt: &past},
+			"alive":   {ID: "alive", BaseURL: "https://example.com", ExpiresAt: &future},
+			"eternal": {ID: "eternal", BaseURL: "https://example.net"},
+		},
+	}
+
+	_, err := s.GetByID(context.Background(), "expired")
+	assert.ErrorIs(t, err, store.ErrExpired)
+
+	n, err := s.SweepExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// swept url is still expired, it isn't deleted.
+	_, err = s.GetByID(context.Background(), "expired")
+	assert.ErrorIs(t, err, store.ErrExpired)
+	assert.True(t, s.urls["expired"].IsSwept)
+	for _, id := range []string{"alive", "eternal"} {
+		_, err = s.GetByID(context.Background(), id)
+		assert.NoError(t, err)
+	}
+
+	n, err = s.SweepExpired(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	restored, err := s.URLsBulkRestore(context.Background(), []string{"expired"}, "")
+	require.NoError(t, err)
+	assert.Zero(t, restored)
+	purged, err := s.PurgeDeleted(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
+
+func TestStore_GetLinkStats(t *testing.T) {
+	s := New()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(context.Background(), u))
+
+	_, err = s.GetLinkStats(context.Background(), "unknown")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	require.NoError(t, s.SaveClicks(context.Background(), []*model.Click{
+		{URLID: u.ID, Time: time.Now()},
+		{URLID: u.ID, Time: time.Now()},
+	}))
+	stats, err := s.GetLinkStats(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Total)
+	assert.Equal(t, "marlo", stats.User)
+	assert.Len(t, stats.Daily, 1)
+}
+
+func TestStore_GetData(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	s := &Store{
+		urls: map[string]*model.URL{
+			"first":   {ID: "first", User: "marlo", BaseURL: "https://example.org"},
+			"second":  {ID: "second", User: "marlo", BaseURL: "https://example.com"},
+			"third":   {ID: "third", User: "vlad", BaseURL: "https://example.net"},
+			"deleted": {ID: "deleted", User: "other", BaseURL: "https://example.org", IsDeleted: true},
+			"expired": {ID: "expired", User: "other", BaseURL: "https://example.org", ExpiresAt: &past},
+		},
+	}
+	stat, err := s.GetData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &model.InternalStat{CountOfURLs: 3, CountOfUsers: 2}, stat)
+}
+
+func TestStore_Snapshot(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	ctx := context.Background()
+
+	s, err := NewWithSnapshot(filename, 0)
+	require.NoError(t, err)
+	alive, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	deleted, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, alive))
+	require.NoError(t, s.Create(ctx, deleted))
+	require.NoError(t, s.URLsBulkDelete([]string{deleted.ID}, "marlo"))
+	require.NoError(t, s.Close())
+
+	restored, err := NewWithSnapshot(filename, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, restored.Close())
+	}()
+
+	got, err := restored.GetByID(ctx, alive.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alive.BaseURL, got.BaseURL)
+	assert.Equal(t, "marlo", got.User)
+	_, err = restored.GetByID(ctx, deleted.ID)
+	assert.ErrorIs(t, err, store.ErrIsDeleted)
+
+	matches, err := filepath.Glob(filename + ".*.tmp")
+	require.NoError(t, err)
+	assert.Empty(t, matches, "temporary files must be removed")
+}
+
+func TestStore_Snapshot_Interval(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	s, err := NewWithSnapshot(filename, 10*time.Millisecond)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(context.Background(), u))
+
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(filename)
+		return err == nil && strings.Contains(string(data), u.ID)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewWithSnapshot_Corrupted(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(filename, []byte("{not json"), 0664))
+	_, err := NewWithSnapshot(filename, 0)
+	assert.Error(t, err)
+}
+
+func TestStore_Create_IDCollision(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	taken, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, taken))
+
+	// generator returns taken and reserved ids first, so store must regenerate them.
+	ids := []string{taken.ID, "API", taken.ID, "free"}
+	gen := model.IDGeneratorFunc(func(context.Context) (string, error) {
+		id := ids[0]
+		ids = ids[1:]
+		return id, nil
+	})
+	u, err := model.NewURLWithGenerator(ctx, gen, "https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	assert.Equal(t, "free", u.ID)
+
+	// generator which always returns taken id exhausts attempts.
+	stuck := model.IDGeneratorFunc(func(context.Context) (string, error) {
+		return taken.ID, nil
+	})
+	u, err = model.NewURLWithGenerator(ctx, stuck, "https://example.net", "marlo")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Create(ctx, u), store.ErrIDCollision)
+}
+
+func TestStore_Create_SequentialCollision(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	gen, err := idgen.NewSequential(s)
+	require.NoError(t, err)
+	// next value of counter is encoded as "100".
+	s.seq = 62*62 - 1
+	for _, alias := range []string{"100", "103"} {
+		u, err := model.NewURL("https://example.org/"+alias, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, u.Apply(model.WithAlias(alias)))
+		require.NoError(t, s.Create(ctx, u))
+	}
+
+	// generator takes next value of counter from store, so it must be called without lock of store.
+	u, err := model.NewURLWithGenerator(ctx, gen, "https://example.com", "marlo")
+	require.NoError(t, err)
+	require.Equal(t, "100", u.ID)
+	require.NoError(t, s.Create(ctx, u))
+	assert.Equal(t, "101", u.ID)
+
+	var batch []*model.URL
+	for _, base := range []string{"https://example.net", "https://example.info"} {
+		u, err := model.NewURLWithGenerator(ctx, gen, base, "marlo")
+		require.NoError(t, err)
+		batch = append(batch, u)
+	}
+	res, err := s.URLsBulkCreate(ctx, batch)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "102", res[0].ShortURL)
+	assert.Equal(t, "104", res[1].ShortURL)
+}
+
+func TestStore_NextSequence(t *testing.T) {
+	s := New()
+	for want := uint64(1); want <= 3; want++ {
+		got, err := s.NextSequence(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestStore_Create_DedupScope(t *testing.T) {
+	ctx := context.Background()
+	tt := []struct {
+		scope         store.DedupScope
+		sameUserErr   error
+		otherUserErr  error
+		wantOtherUser bool
+	}{
+		{scope: store.DedupGlobal, sameUserErr: store.ErrAlreadyExists, otherUserErr: store.ErrAlreadyExists},
+		{scope: store.DedupUser, sameUserErr: store.ErrAlreadyExists, wantOtherUser: true},
+		{scope: store.DedupNone, wantOtherUser: true},
+	}
+	for _, tc := range tt {
+		t.Run(string(tc.scope), func(t *testing.T) {
+			s := New()
+			s.SetDedupScope(tc.scope)
+			first, err := model.NewURL("https://example.org", "a")
+			require.NoError(t, err)
+			require.NoError(t, s.Create(ctx, first))
+
+			same, err := model.NewURL("https://example.org", "a")
+			require.NoError(t, err)
+			err = s.Create(ctx, same)
+			if tc.sameUserErr != nil {
+				assert.ErrorIs(t, err, tc.sameUserErr)
+				assert.Equal(t, first.ID, same.ID)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, first.ID, same.ID)
+			}
+
+			other, err := model.NewURL("https://example.org", "b")
+			require.NoError(t, err)
+			err = s.Create(ctx, other)
+			if tc.otherUserErr != nil {
+				assert.ErrorIs(t, err, tc.otherUserErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			page, err := s.ListUserURLs(ctx, "b", store.ListOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOtherUser, len(page.URLs) == 1)
+		})
+	}
+}
+
+func TestStore_RestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	var urls []*model.URL
+	for _, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, s.Create(ctx, u))
+		urls = append(urls, u)
+	}
+	require.NoError(t, s.URLsBulkDelete([]string{urls[0].ID, urls[1].ID, "unknown"}, "marlo"))
+	assert.False(t, urls[0].IsDeleted, "urls which were returned before must not be changed")
+	require.NoError(t, s.SaveClicks(ctx, []*model.Click{{URLID: urls[1].ID, Time: time.Now()}}))
+
+	// only owner is able to restore url
+	n, err := s.URLsBulkRestore(ctx, []string{urls[0].ID}, "another")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	restoredAt := model.Now()
+	n, err = s.URLsBulkRestore(ctx, []string{urls[0].ID, urls[2].ID}, "marlo")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "only deleted urls are restored")
+	restored, err := s.GetByID(ctx, urls[0].ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.False(t, restored.UpdatedAt.Before(restoredAt))
+
+	// url is deleted just now, so it isn't purged if it was deleted before an hour ago
+	n, err = s.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	n, err = s.PurgeDeleted(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = s.GetByID(ctx, urls[1].ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.GetLinkStats(ctx, urls[1].ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	assert.Empty(t, s.clicks[urls[1].ID])
+
+	// purged url doesn't block creation of duplicate
+	u, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	assert.NoError(t, s.Create(ctx, u))
+}
+
+func TestStore_UpdateURL_Destination(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "snapshot")
+	s, err := NewWithSnapshot(filename, 0)
+	require.NoError(t, err)
+
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	other, err := model.NewURL("https://example.net", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, other))
+
+	_, err = s.UpdateURL(ctx, "unknown", model.WithDestination("https://example.com", ""))
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.UpdateURL(ctx, u.ID, model.WithDestination(other.BaseURL, ""))
+	assert.ErrorIs(t, err, store.ErrAlreadyExists)
+
+	for _, dest := range []string{"https://example.com", "https://example.io"} {
+		updated, err := s.UpdateURL(ctx, u.ID, model.WithDestination(dest, ""))
+		require.NoError(t, err)
+		assert.Equal(t, dest, updated.BaseURL)
+	}
+	assert.Equal(t, "https://example.org", u.BaseURL, "url which was returned before must not be changed")
+
+	// previous destination is free to be shortened again
+	again, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, again))
+	assert.NotEqual(t, u.ID, again.ID)
+	require.NoError(t, s.Close())
+
+	s, err = NewWithSnapshot(filename, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	got, err := s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.io", got.BaseURL)
+	history, err := s.GetHistory(ctx, u.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "https://example.org", history[0].OriginalURL)
+	assert.Equal(t, "https://example.com", history[1].OriginalURL)
+	assert.False(t, history[1].ReplacedAt.Before(history[0].ReplacedAt))
+}
+
+func TestStore_UpdateURL_Settings(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	u, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+
+	_, err = s.UpdateURL(ctx, "unknown", model.WithRedirectCode(301))
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	// url isn't changed partially if any option is bad.
+	_, err = s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.com", ""), model.WithRedirectCode(200))
+	assert.ErrorIs(t, err, model.ErrRedirectCodeBad)
+	got, err := s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org", got.BaseURL)
+	history, err := s.GetHistory(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	updated, err := s.UpdateURL(ctx, u.ID, model.WithDestination("https://example.com", ""), model.WithRedirectCode(301))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", updated.BaseURL)
+	assert.Equal(t, 301, updated.Redirect())
+	assert.Equal(t, model.DefaultRedirectCode, u.Redirect(), "url which was returned before must not be changed")
+	got, err = s.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 301, got.Redirect())
+	history, err = s.GetHistory(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	require.NoError(t, s.URLsBulkDelete([]string{u.ID}, "marlo"))
+	_, err = s.UpdateURL(ctx, u.ID, model.WithRedirectCode(308))
+	assert.ErrorIs(t, err, store.ErrIsDeleted)
+}
+
+func TestStore_TakeClick(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	unlimited, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, unlimited))
+	limited, err := model.NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, limited.Apply(model.WithMaxClicks(5)))
+	require.NoError(t, s.Create(ctx, limited))
+
+	_, err = s.TakeClick(ctx, "unknown")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	u, err := s.TakeClick(ctx, unlimited.ID)
+	require.NoError(t, err)
+	assert.Equal(t, unlimited, u)
+
+	var (
+		wg    sync.WaitGroup
+		taken int64
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.TakeClick(ctx, limited.ID); err == nil {
+				atomic.AddInt64(&taken, 1)
+			} else {
+				assert.ErrorIs(t, err, store.ErrExhausted)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(5), taken)
+	u, err = s.GetByID(ctx, limited.ID)
+	require.NoError(t, err)
+	assert.True(t, u.IsExhausted())
+	assert.Equal(t, int64(5), limited.ClicksLeft, "url which was returned before must not be changed")
+}
+
+func TestStore_Create_Protected(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	plain, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, plain))
+
+	protected, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, protected.Apply(model.WithPassword("secret")))
+	require.NoError(t, s.Create(ctx, protected))
+	assert.NotEqual(t, plain.ID, protected.ID)
+
+	// url which gets password later isn't returned to clients which didn't set password.
+	_, err = s.UpdateURL(ctx, plain.ID, model.WithPassword("secret"))
+	require.NoError(t, err)
+	u, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	assert.NotEqual(t, plain.ID, u.ID)
+	assert.NotEqual(t, protected.ID, u.ID)
+}
+
+func TestStore_Create_Deleted(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	deleted, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, deleted))
+	require.NoError(t, s.URLsBulkDelete([]string{deleted.ID}, "a"))
+
+	// deleted url isn't duplicate, so url is created anew.
+	u, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	assert.NotEqual(t, deleted.ID, u.ID)
+
+	dup, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	assert.Equal(t, u.ID, dup.ID)
+}
@@ -0,0 +1,234 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+// ListSort is order of urls in list. Sort with '-' prefix is descending.
+type ListSort string
+
+// const ...
+const (
+	// SortCreatedAsc sorts urls from oldest to newest.
+	SortCreatedAsc ListSort = "created_at"
+	// SortCreatedDesc sorts urls from newest to oldest.
+	SortCreatedDesc ListSort = "-created_at"
+	// SortURLAsc sorts urls by original url alphabetically.
+	SortURLAsc ListSort = "original_url"
+	// SortURLDesc sorts urls by original url in reverse alphabetical order.
+	SortURLDesc ListSort = "-original_url"
+
+	// DefaultListLimit is count of urls in page if limit is not provided.
+	DefaultListLimit = 100
+	// MaxListLimit is max count of urls in page.
+	MaxListLimit = 1000
+)
+
+// ErrBadListOptions ...
+var ErrBadListOptions = errors.New("bad list options")
+
+// ListOptions are options of user urls listing.
+type ListOptions struct {
+	// Limit is max count of urls in page. Zero means DefaultListLimit, limits greater than MaxListLimit
+	// are reduced to it.
+	Limit int
+	// Cursor is cursor of page which is returned with previous page. Empty cursor means first page.
+	Cursor string
+	// Query filters urls which original url contains it case-insensitively.
+	Query string
+	// CreatedFrom filters urls which are created at or after it if it is not zero.
+	CreatedFrom time.Time
+	// CreatedTo filters urls which are created before it if it is not zero.
+	CreatedTo time.Time
+	// Tag filters urls which are tagged with it.
+	Tag string
+	// Sort is order of urls. Zero sort is SortCreatedAsc.
+	Sort ListSort
+}
+
+// Page is page of user urls. Next page is requested with NextCursor; empty NextCursor means that page is last.
+type Page struct {
+	URLs       []*model.URL
+	NextCursor string
+}
+
+// Cursor is position in list of urls. It is key of last url of page by which urls are sorted.
+type Cursor struct {
+	Sort      ListSort  `json:"s"`
+	CreatedAt time.Time `json:"c,omitempty"`
+	URL       string    `json:"u,omitempty"`
+	ID        string    `json:"i"`
+}
+
+// NewListOptions parses list options which are provided by transport as strings. Dates must be in
+// RFC 3339 format.
+func NewListOptions(limit int, cursor, query, createdFrom, createdTo, tag, sort string) (opts ListOptions, err error) {
+	opts = ListOptions{
+		Limit:  limit,
+		Cursor: cursor,
+		Query:  query,
+		Tag:    tag,
+		Sort:   ListSort(sort),
+	}
+	if createdFrom != "" {
+		if opts.CreatedFrom, err = time.Parse(time.RFC3339, createdFrom); err != nil {
+			return opts, fmt.Errorf("%w: created from must be in RFC 3339 format", ErrBadListOptions)
+		}
+	}
+	if createdTo != "" {
+		if opts.CreatedTo, err = time.Parse(time.RFC3339, createdTo); err != nil {
+			return opts, fmt.Errorf("%w: created to must be in RFC 3339 format", ErrBadListOptions)
+		}
+	}
+	_, _, err = opts.Normalize()
+	return opts, err
+}
+
+// Normalize returns options with default values set and decoded cursor, which is nil for first page.
+func (o ListOptions) Normalize() (ListOptions, *Cursor, error) {
+	switch {
+	case o.Limit < 0:
+		return o, nil, fmt.Errorf("%w: limit must not be negative", ErrBadListOptions)
+	case o.Limit == 0:
+		o.Limit = DefaultListLimit
+	case o.Limit > MaxListLimit:
+		o.Limit = MaxListLimit
+	}
+	switch o.Sort {
+	case "":
+		o.Sort = SortCreatedAsc
+	case SortCreatedAsc, SortCreatedDesc, SortURLAsc, SortURLDesc:
+	default:
+		return o, nil, fmt.Errorf("%w: unknown sort %q", ErrBadListOptions, o.Sort)
+	}
+	if o.Tag != "" {
+		tag, err := model.NormalizeTag(o.Tag)
+		if err != nil {
+			return o, nil, fmt.Errorf("%w: %v", ErrBadListOptions, err)
+		}
+		o.Tag = tag
+	}
+	if o.Cursor == "" {
+		return o, nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(o.Cursor)
+	if err != nil {
+		return o, nil, fmt.Errorf("%w: bad cursor", ErrBadListOptions)
+	}
+	c := new(Cursor)
+	if err = json.Unmarshal(data, c); err != nil || c.ID == "" {
+		return o, nil, fmt.Errorf("%w: bad cursor", ErrBadListOptions)
+	}
+	if c.Sort != o.Sort {
+		return o, nil, fmt.Errorf("%w: cursor belongs to list with other sort", ErrBadListOptions)
+	}
+	return o, c, nil
+}
+
+// Descending reports whether urls are sorted in descending order.
+func (s ListSort) Descending() bool {
+	return strings.HasPrefix(string(s), "-")
+}
+
+// Match reports whether u passes filters of options.
+func (o ListOptions) Match(u *model.URL) bool {
+	if o.Query != "" && !strings.Contains(strings.ToLower(u.BaseURL), strings.ToLower(o.Query)) {
+		return false
+	}
+	if !o.CreatedFrom.IsZero() && u.CreatedAt.Before(o.CreatedFrom) {
+		return false
+	}
+	if !o.CreatedTo.IsZero() && !u.CreatedAt.Before(o.CreatedTo) {
+		return false
+	}
+	if o.Tag != "" && !u.HasTag(o.Tag) {
+		return false
+	}
+	return true
+}
+
+// NewCursor returns cursor which points to u in list sorted by s.
+func NewCursor(s ListSort, u *model.URL) *Cursor {
+	c := &Cursor{Sort: s, ID: u.ID}
+	switch s {
+	case SortURLAsc, SortURLDesc:
+		c.URL = u.BaseURL
+	default:
+		c.CreatedAt = u.CreatedAt
+	}
+	return c
+}
+
+// String returns encoded cursor.
+func (c *Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// compare compares url with cursor position in ascending order. Urls with equal keys are ordered by id.
+func (c *Cursor) compare(u *model.URL) int {
+	var res int
+	switch c.Sort {
+	case SortURLAsc, SortURLDesc:
+		res = strings.Compare(u.BaseURL, c.URL)
+	default:
+		switch {
+		case u.CreatedAt.Before(c.CreatedAt):
+			res = -1
+		case u.CreatedAt.After(c.CreatedAt):
+			res = 1
+		}
+	}
+	if res == 0 {
+		res = strings.Compare(u.ID, c.ID)
+	}
+	return res
+}
+
+// After reports whether u is placed after cursor in list.
+func (c *Cursor) After(u *model.URL) bool {
+	if c.Sort.Descending() {
+		return c.compare(u) < 0
+	}
+	return c.compare(u) > 0
+}
+
+// Paginate returns page of urls which match options. It is used by stores which keep urls in memory;
+// urls are sorted in place.
+func Paginate(urls []*model.URL, opts ListOptions) (*Page, error) {
+	opts, cursor, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	filtered := urls[:0]
+	for _, u := range urls {
+		if opts.Match(u) && (cursor == nil || cursor.After(u)) {
+			filtered = append(filtered, u)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return NewCursor(opts.Sort, filtered[i]).After(filtered[j])
+	})
+	return NewPage(filtered, opts), nil
+}
+
+// NewPage returns page of first urls which are already filtered and sorted. Stores request one url more
+// than limit of options to know whether next page exists.
+func NewPage(urls []*model.URL, opts ListOptions) *Page {
+	if len(urls) <= opts.Limit {
+		return &Page{URLs: urls}
+	}
+	urls = urls[:opts.Limit]
+	return &Page{
+		URLs:       urls,
+		NextCursor: NewCursor(opts.Sort, urls[len(urls)-1]).String(),
+	}
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vlad-marlo/shortener/internal/store/model"
+)
+
+func testURLs() []*model.URL {
+	t := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*model.URL{
+		{ID: "a", BaseURL: "https://b.org", CreatedAt: t, Tags: []string{"news"}},
+		{ID: "b", BaseURL: "https://a.org", CreatedAt: t.Add(time.Hour), Tags: []string{"ads", "news"}},
+		{ID: "c", BaseURL: "https://c.com", CreatedAt: t},
+		{ID: "d", BaseURL: "https://d.org", CreatedAt: t.Add(2 * time.Hour)},
+	}
+}
+
+func ids(urls []*model.URL) (res []string) {
+	for _, u := range urls {
+		res = append(res, u.ID)
+	}
+	return
+}
+
+func TestPaginate(t *testing.T) {
+	tt := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{name: "default", want: []string{"a", "c", "b", "d"}},
+		{name: "created desc", opts: ListOptions{Sort: SortCreatedDesc}, want: []string{"d", "b", "c", "a"}},
+		{name: "url asc", opts: ListOptions{Sort: SortURLAsc}, want: []string{"b", "a", "c", "d"}},
+		{name: "url desc", opts: ListOptions{Sort: SortURLDesc}, want: []string{"d", "c", "a", "b"}},
+		{name: "query", opts: ListOptions{Query: ".ORG"}, want: []string{"a", "b", "d"}},
+		{name: "tag", opts: ListOptions{Tag: "News"}, want: []string{"a", "b"}},
+		{
+			name: "created range",
+			opts: ListOptions{
+				CreatedFrom: time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC),
+				CreatedTo:   time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC),
+			},
+			want: []string{"b"},
+		},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// every page is requested with cursor of previous one
+			var got []string
+			opts := tc.opts
+			opts.Limit = 1
+			for pages := 0; ; pages++ {
+				require.Less(t, pages, 5, "too many pages")
+				page, err := Paginate(testURLs(), opts)
+				require.NoError(t, err)
+				got = append(got, ids(page.URLs)...)
+				if page.NextCursor == "" {
+					break
+				}
+				opts.Cursor = page.NextCursor
+			}
+			assert.Equal(t, tc.want, got)
+
+			page, err := Paginate(testURLs(), tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ids(page.URLs))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+}
+
+func TestListOptions_Normalize(t *testing.T) {
+	opts, cursor, err := ListOptions{}.Normalize()
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, DefaultListLimit, opts.Limit)
+	assert.Equal(t, SortCreatedAsc, opts.Sort)
+
+	opts, _, err = ListOptions{Limit: MaxListLimit + 1}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, MaxListLimit, opts.Limit)
+
+	c := NewCursor(SortURLAsc, testURLs()[0]).String()
+	_, cursor, err = ListOptions{Sort: SortURLAsc, Cursor: c}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, &Cursor{Sort: SortURLAsc, URL: "https://b.org", ID: "a"}, cursor)
+
+	for _, bad := range []ListOptions{
+		{Limit: -1},
+		{Sort: "id"},
+		{Cursor: "?"},
+		{Cursor: "e30"},
+		{Cursor: c},
+	} {
+		_, _, err = bad.Normalize()
+		assert.ErrorIs(t, err, ErrBadListOptions)
+	}
+}
+
+func TestNewListOptions(t *testing.T) {
+	opts, err := NewListOptions(10, "", "q", "2023-01-01T00:00:00Z", "", "News", "-original_url")
+	require.NoError(t, err)
+	assert.Equal(t, ListOptions{
+		Limit:       10,
+		Query:       "q",
+		CreatedFrom: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Tag:         "News",
+		Sort:        SortURLDesc,
+	}, opts)
+
+	_, err = NewListOptions(0, "", "", "", "tomorrow", "", "")
+	assert.ErrorIs(t, err, ErrBadListOptions)
+	_, err = NewListOptions(0, "", "", "", "", "bad tag", "")
+	assert.ErrorIs(t, err, ErrBadListOptions)
+}
@@ -7,8 +7,10 @@ package mock_store
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	store "github.com/vlad-marlo/shortener/internal/store"
 	model "github.com/vlad-marlo/shortener/internal/store/model"
 )
 
@@ -63,21 +65,6 @@ func (mr *MockStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStore)(nil).Create), arg0, arg1)
 }
 
-// GetAllUserURLs mocks base method.
-func (m *MockStore) GetAllUserURLs(arg0 context.Context, arg1 string) ([]*model.URL, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUserURLs", arg0, arg1)
-	ret0, _ := ret[0].([]*model.URL)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAllUserURLs indicates an expected call of GetAllUserURLs.
-func (mr *MockStoreMockRecorder) GetAllUserURLs(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserURLs", reflect.TypeOf((*MockStore)(nil).GetAllUserURLs), arg0, arg1)
-}
-
 // GetByID mocks base method.
 func (m *MockStore) GetByID(arg0 context.Context, arg1 string) (*model.URL, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +95,66 @@ func (mr *MockStoreMockRecorder) GetData(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockStore)(nil).GetData), ctx)
 }
 
+// GetHistory mocks base method.
+func (m *MockStore) GetHistory(ctx context.Context, id string) ([]*model.Destination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id)
+	ret0, _ := ret[0].([]*model.Destination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockStoreMockRecorder) GetHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockStore)(nil).GetHistory), ctx, id)
+}
+
+// GetLinkStats mocks base method.
+func (m *MockStore) GetLinkStats(ctx context.Context, id string) (*model.LinkStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkStats", ctx, id)
+	ret0, _ := ret[0].(*model.LinkStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkStats indicates an expected call of GetLinkStats.
+func (mr *MockStoreMockRecorder) GetLinkStats(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStats", reflect.TypeOf((*MockStore)(nil).GetLinkStats), ctx, id)
+}
+
+// ListUserURLs mocks base method.
+func (m *MockStore) ListUserURLs(ctx context.Context, user string, opts store.ListOptions) (*store.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserURLs", ctx, user, opts)
+	ret0, _ := ret[0].(*store.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserURLs indicates an expected call of ListUserURLs.
+func (mr *MockStoreMockRecorder) ListUserURLs(ctx, user, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserURLs", reflect.TypeOf((*MockStore)(nil).ListUserURLs), ctx, user, opts)
+}
+
+// NextSequence mocks base method.
+func (m *MockStore) NextSequence(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextSequence", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextSequence indicates an expected call of NextSequence.
+func (mr *MockStoreMockRecorder) NextSequence(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextSequence", reflect.TypeOf((*MockStore)(nil).NextSequence), ctx)
+}
+
 // Ping mocks base method.
 func (m *MockStore) Ping(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -122,6 +169,65 @@ func (mr *MockStoreMockRecorder) Ping(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockStore)(nil).Ping), arg0)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockStore) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockStoreMockRecorder) PurgeDeleted(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockStore)(nil).PurgeDeleted), ctx, before)
+}
+
+// SaveClicks mocks base method.
+func (m *MockStore) SaveClicks(ctx context.Context, clicks []*model.Click) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClicks", ctx, clicks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClicks indicates an expected call of SaveClicks.
+func (mr *MockStoreMockRecorder) SaveClicks(ctx, clicks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClicks", reflect.TypeOf((*MockStore)(nil).SaveClicks), ctx, clicks)
+}
+
+// SweepExpired mocks base method.
+func (m *MockStore) SweepExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpired indicates an expected call of SweepExpired.
+func (mr *MockStoreMockRecorder) SweepExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpired", reflect.TypeOf((*MockStore)(nil).SweepExpired), ctx)
+}
+
+// TakeClick mocks base method.
+func (m *MockStore) TakeClick(ctx context.Context, id string) (*model.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeClick", ctx, id)
+	ret0, _ := ret[0].(*model.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeClick indicates an expected call of TakeClick.
+func (mr *MockStoreMockRecorder) TakeClick(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeClick", reflect.TypeOf((*MockStore)(nil).TakeClick), ctx, id)
+}
+
 // URLsBulkCreate mocks base method.
 func (m *MockStore) URLsBulkCreate(arg0 context.Context, arg1 []*model.URL) ([]*model.BatchCreateURLsResponse, error) {
 	m.ctrl.T.Helper()
@@ -150,3 +256,38 @@ func (mr *MockStoreMockRecorder) URLsBulkDelete(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLsBulkDelete", reflect.TypeOf((*MockStore)(nil).URLsBulkDelete), arg0, arg1)
 }
+
+// URLsBulkRestore mocks base method.
+func (m *MockStore) URLsBulkRestore(ctx context.Context, ids []string, user string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URLsBulkRestore", ctx, ids, user)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// URLsBulkRestore indicates an expected call of URLsBulkRestore.
+func (mr *MockStoreMockRecorder) URLsBulkRestore(ctx, ids, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLsBulkRestore", reflect.TypeOf((*MockStore)(nil).URLsBulkRestore), ctx, ids, user)
+}
+
+// UpdateURL mocks base method.
+func (m *MockStore) UpdateURL(ctx context.Context, id string, opts ...model.URLOption) (*model.URL, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, id}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateURL", varargs...)
+	ret0, _ := ret[0].(*model.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateURL indicates an expected call of UpdateURL.
+func (mr *MockStoreMockRecorder) UpdateURL(ctx, id interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, id}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURL", reflect.TypeOf((*MockStore)(nil).UpdateURL), varargs...)
+}
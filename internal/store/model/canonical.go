@@ -0,0 +1,139 @@
+package model
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts are ports which are dropped from canonical urls with corresponding schemes.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+// Canonicalize returns canonical form of raw url, so urls which differ only in notation have same canonical
+// form:
+//
+//   - scheme and host are lower-cased, internationalized host is converted to punycode;
+//   - default port of scheme is dropped;
+//   - empty path of http(s) url is replaced with "/";
+//   - query parameters are sorted by name, order of values of one parameter is kept, empty query is dropped;
+//     parameters are not decoded, so they are redirected to as they were provided;
+//   - fragment is dropped if dropFragment is true.
+//
+// Url without scheme or host or url which can't be parsed is returned as is.
+func Canonicalize(raw string, dropFragment bool) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if net.ParseIP(host) == nil {
+		if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+			host = ascii
+		}
+	}
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	switch {
+	case port != "":
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	if u.Path == "" && (u.Scheme == "http" || u.Scheme == "https") {
+		u.Path = "/"
+	}
+	u.ForceQuery = false
+	if u.RawQuery != "" {
+		// query which can't be parsed is kept as is, so no parameter of it is lost.
+		if _, err := url.ParseQuery(u.RawQuery); err == nil {
+			u.RawQuery = sortQuery(u.RawQuery)
+		}
+	}
+	if dropFragment {
+		u.Fragment, u.RawFragment = "", ""
+	}
+	return u.String()
+}
+
+// sortQuery returns raw query with parameters which are stably sorted by raw name. Empty parameters are
+// dropped.
+func sortQuery(raw string) string {
+	params := make([]string, 0, strings.Count(raw, "&")+1)
+	for _, param := range strings.Split(raw, "&") {
+		if param != "" {
+			params = append(params, param)
+		}
+	}
+	name := func(param string) string {
+		name, _, _ := strings.Cut(param, "=")
+		return name
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return name(params[i]) < name(params[j])
+	})
+	return strings.Join(params, "&")
+}
+
+// WithCanonicalURL replaces original url of url with its canonical form, so urls are deduplicated by
+// canonical forms. Original string is kept as RawURL if it differs from canonical form. Destinations of
+// rules and variants are canonicalized too, so it must be applied after options which set them.
+func WithCanonicalURL(dropFragment bool) URLOption {
+	return func(u *URL) error {
+		canonical := Canonicalize(u.BaseURL, dropFragment)
+		if canonical != u.BaseURL {
+			u.RawURL = u.BaseURL
+			u.BaseURL = canonical
+		}
+		return WithCanonicalDestinations(dropFragment)(u)
+	}
+}
+
+// WithCanonicalDestinations replaces destinations of rules and variants of url with their canonical forms,
+// so they are checked and redirected to same way as original url. Rules and variants are replaced with
+// changed copies, so copies of url which share them are not changed.
+func WithCanonicalDestinations(dropFragment bool) URLOption {
+	return func(u *URL) error {
+		if len(u.Rules) != 0 {
+			rules := make([]*Rule, 0, len(u.Rules))
+			for _, r := range u.Rules {
+				canonical := *r
+				canonical.Destination = Canonicalize(r.Destination, dropFragment)
+				rules = append(rules, &canonical)
+			}
+			u.Rules = rules
+		}
+		if len(u.Variants) != 0 {
+			variants := make([]*Variant, 0, len(u.Variants))
+			for _, v := range u.Variants {
+				canonical := *v
+				canonical.Destination = Canonicalize(v.Destination, dropFragment)
+				variants = append(variants, &canonical)
+			}
+			u.Variants = variants
+		}
+		return nil
+	}
+}
+
+// Display returns original url of url as it was provided by user.
+func (u *URL) Display() string {
+	if u.RawURL != "" {
+		return u.RawURL
+	}
+	return u.BaseURL
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tt := []struct {
+		name         string
+		raw          string
+		dropFragment bool
+		want         string
+	}{
+		{name: "case and default port", raw: "HTTPS://Example.COM:443/a?b=1&a=2", want: "https://example.com/a?a=2&b=1"},
+		{name: "canonical", raw: "https://example.com/a?a=2&b=1", want: "https://example.com/a?a=2&b=1"},
+		{name: "custom port", raw: "http://example.com:8080", want: "http://example.com:8080/"},
+		{name: "http default port", raw: "http://example.com:80/", want: "http://example.com/"},
+		{name: "path case is kept", raw: "https://example.com/Path", want: "https://example.com/Path"},
+		{name: "values order is kept", raw: "https://example.com/?b=2&a=1&b=1", want: "https://example.com/?a=1&b=2&b=1"},
+		{name: "empty query", raw: "https://example.com/?", want: "https://example.com/"},
+		{name: "flag is kept", raw: "https://example.com/?flag&a=1", want: "https://example.com/?a=1&flag"},
+		{name: "values are not escaped", raw: "https://example.com/?redirect=http://x&a=b+c", want: "https://example.com/?a=b+c&redirect=http://x"},
+		{name: "idn", raw: "https://Пример.рф/", want: "https://xn--e1afmkfd.xn--p1ai/"},
+		{name: "ipv6", raw: "http://[::1]:80/", want: "http://[::1]/"},
+		{name: "fragment is kept", raw: "https://example.com/#Top", want: "https://example.com/#Top"},
+		{name: "fragment is dropped", raw: "https://example.com/#Top", dropFragment: true, want: "https://example.com/"},
+		{name: "bad query is kept", raw: "https://example.com/?b=%zz&a=1", want: "https://example.com/?b=%zz&a=1"},
+		{name: "no host", raw: "mailto:Marlo@Example.com", want: "mailto:Marlo@Example.com"},
+		{name: "not url", raw: "not url", want: "not url"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Canonicalize(tc.raw, tc.dropFragment))
+		})
+	}
+}
+
+func TestWithCanonicalURL(t *testing.T) {
+	u, err := NewURL("HTTPS://Example.com:443/a?b=1&a=2", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(WithCanonicalURL(false)))
+	assert.Equal(t, "https://example.com/a?a=2&b=1", u.BaseURL)
+	assert.Equal(t, "HTTPS://Example.com:443/a?b=1&a=2", u.RawURL)
+	assert.Equal(t, u.RawURL, u.Display())
+
+	canonical, err := NewURL("https://example.com/a?a=2&b=1", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, canonical.Apply(WithCanonicalURL(false)))
+	assert.Empty(t, canonical.RawURL)
+	assert.Equal(t, canonical.BaseURL, canonical.Display())
+
+	require.NoError(t, u.Apply(WithDestination("https://example.org/", "")))
+	assert.Empty(t, u.RawURL)
+}
+
+func TestWithCanonicalURL_Destinations(t *testing.T) {
+	u, err := NewURL("https://example.com", "marlo")
+	require.NoError(t, err)
+	rules := []*Rule{{Device: DeviceIOS, Destination: "HTTP://Example.com:80"}}
+	require.NoError(t, u.Apply(
+		WithRules(rules),
+		WithVariants([]*Variant{{Name: "a", Destination: "HTTPS://Example.org/?b=1&a=2", Weight: 1}}),
+		WithCanonicalURL(false),
+	))
+	assert.Equal(t, "http://example.com/", u.Rules[0].Destination)
+	assert.Equal(t, "https://example.org/?a=2&b=1", u.Variants[0].Destination)
+	assert.Equal(t, []string{"https://example.com/", "http://example.com/", "https://example.org/?a=2&b=1"}, u.Destinations())
+	assert.Equal(t, "HTTP://Example.com:80", rules[0].Destination, "provided rules must not be changed")
+}
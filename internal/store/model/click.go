@@ -0,0 +1,92 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// clickDateLayout ...
+const clickDateLayout = "2006-01-02"
+
+// types ...
+type (
+	// Click is record about one redirect by short url.
+	Click struct {
+		URLID     string    `json:"id"`
+		Time      time.Time `json:"time"`
+		Referer   string    `json:"referer,omitempty"`
+		UserAgent string    `json:"user_agent,omitempty"`
+		IP        string    `json:"ip,omitempty"`
+		// Variant is name of split variant of url which visitor was redirected to.
+		Variant string `json:"variant,omitempty"`
+	}
+
+	// DailyClicks ...
+	DailyClicks struct {
+		Date  string `json:"date"`
+		Count int64  `json:"count"`
+	}
+
+	// VariantClicks is count of clicks which were redirected to split variant of url.
+	VariantClicks struct {
+		Name  string `json:"name"`
+		Count int64  `json:"count"`
+	}
+
+	// LinkStats is aggregated clicks of one url.
+	LinkStats struct {
+		ID    string         `json:"id"`
+		User  string         `json:"-"`
+		Total int64          `json:"total"`
+		Daily []*DailyClicks `json:"daily"`
+		// Variants are counts of clicks per split variant, ordered by name of variant.
+		Variants []*VariantClicks `json:"variants,omitempty"`
+	}
+)
+
+// Date returns day of click in UTC.
+func (c *Click) Date() string {
+	return c.Time.UTC().Format(clickDateLayout)
+}
+
+// NewLinkStats aggregates clicks of url with provided id into per-day and per-variant counts.
+func NewLinkStats(id, user string, clicks []*Click) *LinkStats {
+	stats := &LinkStats{
+		ID:    id,
+		User:  user,
+		Daily: []*DailyClicks{},
+	}
+	days := make(map[string]*DailyClicks)
+	variants := make(map[string]*VariantClicks)
+	for _, c := range clicks {
+		if c.URLID != id {
+			continue
+		}
+		stats.Total++
+		date := c.Date()
+		d, ok := days[date]
+		if !ok {
+			d = &DailyClicks{Date: date}
+			days[date] = d
+			stats.Daily = append(stats.Daily, d)
+		}
+		d.Count++
+		if c.Variant == "" {
+			continue
+		}
+		v, ok := variants[c.Variant]
+		if !ok {
+			v = &VariantClicks{Name: c.Variant}
+			variants[c.Variant] = v
+			stats.Variants = append(stats.Variants, v)
+		}
+		v.Count++
+	}
+	sort.Slice(stats.Daily, func(i, j int) bool {
+		return stats.Daily[i].Date < stats.Daily[j].Date
+	})
+	sort.Slice(stats.Variants, func(i, j int) bool {
+		return stats.Variants[i].Name < stats.Variants[j].Name
+	})
+	return stats
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLinkStats(t *testing.T) {
+	day1 := time.Date(2023, 1, 2, 23, 59, 0, 0, time.UTC)
+	day2 := time.Date(2023, 1, 3, 0, 1, 0, 0, time.UTC)
+	clicks := []*Click{
+		{URLID: "a", Time: day2, Variant: "b"},
+		{URLID: "a", Time: day1, Variant: "a"},
+		{URLID: "b", Time: day1, Variant: "a"},
+		{URLID: "a", Time: day2.Add(time.Hour), Variant: "b"},
+		{URLID: "a", Time: day2.Add(time.Hour)},
+	}
+
+	stats := NewLinkStats("a", "marlo", clicks)
+	assert.Equal(t, "a", stats.ID)
+	assert.Equal(t, "marlo", stats.User)
+	assert.Equal(t, int64(4), stats.Total)
+	assert.Equal(t, []*DailyClicks{
+		{Date: "2023-01-02", Count: 1},
+		{Date: "2023-01-03", Count: 3},
+	}, stats.Daily)
+	assert.Equal(t, []*VariantClicks{
+		{Name: "a", Count: 1},
+		{Name: "b", Count: 2},
+	}, stats.Variants)
+
+	empty := NewLinkStats("c", "marlo", clicks)
+	assert.Equal(t, int64(0), empty.Total)
+	assert.Empty(t, empty.Daily)
+	assert.Empty(t, empty.Variants)
+}
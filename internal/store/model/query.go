@@ -0,0 +1,146 @@
+package model
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// UTMValueMaxLength ...
+const UTMValueMaxLength = 256
+
+// vars ...
+var (
+	// ErrUTMEmpty ...
+	ErrUTMEmpty = errors.New("utm must have at least one parameter")
+	// ErrUTMTooLong ...
+	ErrUTMTooLong = errors.New("utm parameters must be at most 256 chars long")
+)
+
+// UTM is template of utm parameters which are added to destination of url on redirect.
+type UTM struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// params returns query parameters of utm which are not empty.
+func (t *UTM) params() url.Values {
+	params := make(url.Values)
+	for _, p := range []struct{ key, value string }{
+		{"utm_source", t.Source},
+		{"utm_medium", t.Medium},
+		{"utm_campaign", t.Campaign},
+		{"utm_term", t.Term},
+		{"utm_content", t.Content},
+	} {
+		if p.value != "" {
+			params.Set(p.key, p.value)
+		}
+	}
+	return params
+}
+
+// WithPassthrough enables forwarding of query of short url to destination of url.
+func WithPassthrough() URLOption {
+	return func(u *URL) error {
+		u.Passthrough = true
+		return nil
+	}
+}
+
+// WithoutPassthrough disables forwarding of query of short url to destination of url.
+func WithoutPassthrough() URLOption {
+	return func(u *URL) error {
+		u.Passthrough = false
+		return nil
+	}
+}
+
+// WithUTM sets utm template of url. Nil utm is ignored.
+func WithUTM(utm *UTM) URLOption {
+	return func(u *URL) error {
+		if utm == nil {
+			return nil
+		}
+		res := &UTM{
+			Source:   strings.TrimSpace(utm.Source),
+			Medium:   strings.TrimSpace(utm.Medium),
+			Campaign: strings.TrimSpace(utm.Campaign),
+			Term:     strings.TrimSpace(utm.Term),
+			Content:  strings.TrimSpace(utm.Content),
+		}
+		params := res.params()
+		if len(params) == 0 {
+			return ErrUTMEmpty
+		}
+		for key := range params {
+			if len(params.Get(key)) > UTMValueMaxLength {
+				return ErrUTMTooLong
+			}
+		}
+		u.UTM = res
+		return nil
+	}
+}
+
+// WithoutUTM removes utm template of url.
+func WithoutUTM() URLOption {
+	return func(u *URL) error {
+		u.UTM = nil
+		return nil
+	}
+}
+
+// Location returns target of url with query parameters of url added to it. Parameters are merged with
+// following precedence, from lowest to highest:
+//
+//   - parameters of target itself;
+//   - utm template of url, its parameters are added only if target has no parameter with same name;
+//   - rawQuery of short url if passthrough is enabled, its parameters replace all values of parameters with
+//     same name.
+//
+// Target is returned as is if no parameter is added. Otherwise query of target is re-encoded with
+// url.Values.Encode: parameters are sorted by name and escaped. Target with query which can't be parsed
+// is returned as is too.
+func (u *URL) Location(target, rawQuery string) string {
+	var incoming url.Values
+	if u.Passthrough && rawQuery != "" {
+		// bad pairs of query are skipped, so the rest of query is still forwarded.
+		incoming, _ = url.ParseQuery(rawQuery)
+	}
+	if u.UTM == nil && len(incoming) == 0 {
+		return target
+	}
+
+	dst, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	query, err := url.ParseQuery(dst.RawQuery)
+	if err != nil {
+		return target
+	}
+	changed := false
+	if u.UTM != nil {
+		for key, values := range u.UTM.params() {
+			if _, ok := query[key]; !ok {
+				query[key] = values
+				changed = true
+			}
+		}
+	}
+	for key, values := range incoming {
+		if key != "" {
+			query[key] = values
+			changed = true
+		}
+	}
+	if !changed {
+		return target
+	}
+	dst.RawQuery = query.Encode()
+	return dst.String()
+}
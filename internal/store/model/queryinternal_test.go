@@ -0,0 +1,93 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithUTM(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+
+	assert.NoError(t, u.Apply(WithUTM(nil)))
+	assert.Nil(t, u.UTM)
+	assert.ErrorIs(t, u.Apply(WithUTM(&UTM{Source: " "})), ErrUTMEmpty)
+	assert.ErrorIs(t, u.Apply(WithUTM(&UTM{Source: strings.Repeat("a", UTMValueMaxLength+1)})), ErrUTMTooLong)
+	assert.Nil(t, u.UTM)
+
+	require.NoError(t, u.Apply(OptionsOf(&CreateURLRequest{Passthrough: true, UTM: &UTM{Source: " short "}})...))
+	assert.True(t, u.Passthrough)
+	assert.Equal(t, &UTM{Source: "short"}, u.UTM)
+
+	require.NoError(t, u.Apply(OptionsOf(&UpdateURLRequest{DisablePassthrough: true, ClearUTM: true})...))
+	assert.False(t, u.Passthrough)
+	assert.Nil(t, u.UTM)
+}
+
+func TestURL_Location(t *testing.T) {
+	tt := []struct {
+		name        string
+		passthrough bool
+		utm         *UTM
+		target      string
+		query       string
+		want        string
+	}{
+		{
+			name:   "nothing to add",
+			target: "https://example.org/path?b=2&a=1#top",
+			query:  "utm_source=twitter",
+			want:   "https://example.org/path?b=2&a=1#top",
+		},
+		{
+			name:        "passthrough",
+			passthrough: true,
+			target:      "https://example.org/path?b=2#top",
+			query:       "utm_source=twitter&q=a+b",
+			want:        "https://example.org/path?b=2&q=a+b&utm_source=twitter#top",
+		},
+		{
+			name:        "query overrides target",
+			passthrough: true,
+			target:      "https://example.org/?ref=site&ref=other",
+			query:       "ref=twitter",
+			want:        "https://example.org/?ref=twitter",
+		},
+		{
+			name:   "utm doesn't override target",
+			utm:    &UTM{Source: "short", Medium: "link"},
+			target: "https://example.org/?utm_source=site",
+			want:   "https://example.org/?utm_medium=link&utm_source=site",
+		},
+		{
+			name:        "query overrides utm",
+			passthrough: true,
+			utm:         &UTM{Source: "short", Campaign: "spring sale"},
+			target:      "https://example.org/",
+			query:       "utm_source=twitter",
+			want:        "https://example.org/?utm_campaign=spring+sale&utm_source=twitter",
+		},
+		{
+			name:        "bad pairs of query are skipped",
+			passthrough: true,
+			target:      "https://example.org/",
+			query:       "a=%zz&b=1",
+			want:        "https://example.org/?b=1",
+		},
+		{
+			name:   "bad query of target",
+			utm:    &UTM{Source: "short"},
+			target: "https://example.org/?a=%zz",
+			want:   "https://example.org/?a=%zz",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &URL{Passthrough: tc.passthrough, UTM: tc.utm}
+			assert.Equal(t, tc.want, u.Location(tc.target, tc.query))
+		})
+	}
+}
@@ -1,9 +1,104 @@
 package model
 
+// CreateURLRequest ...
+type CreateURLRequest struct {
+	URL          string     `json:"url"`
+	Alias        string     `json:"alias,omitempty"`
+	ExpiresAt    string     `json:"expires_at,omitempty"`
+	TTL          int64      `json:"ttl,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Note         string     `json:"note,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	RedirectCode uint32     `json:"redirect_code,omitempty"`
+	Password     string     `json:"password,omitempty"`
+	MaxClicks    int64      `json:"max_clicks,omitempty"`
+	Rules        []*Rule    `json:"rules,omitempty"`
+	Variants     []*Variant `json:"variants,omitempty"`
+	Passthrough  bool       `json:"passthrough,omitempty"`
+	UTM          *UTM       `json:"utm,omitempty"`
+}
+
+// GetAlias ...
+func (c *CreateURLRequest) GetAlias() string {
+	return c.Alias
+}
+
+// GetExpiresAt ...
+func (c *CreateURLRequest) GetExpiresAt() string {
+	return c.ExpiresAt
+}
+
+// GetTtl ...
+func (c *CreateURLRequest) GetTtl() int64 {
+	return c.TTL
+}
+
+// GetTitle ...
+func (c *CreateURLRequest) GetTitle() string {
+	return c.Title
+}
+
+// GetNote ...
+func (c *CreateURLRequest) GetNote() string {
+	return c.Note
+}
+
+// GetTags ...
+func (c *CreateURLRequest) GetTags() []string {
+	return c.Tags
+}
+
+// GetRedirectCode ...
+func (c *CreateURLRequest) GetRedirectCode() uint32 {
+	return c.RedirectCode
+}
+
+// GetPassword ...
+func (c *CreateURLRequest) GetPassword() string {
+	return c.Password
+}
+
+// GetMaxClicks ...
+func (c *CreateURLRequest) GetMaxClicks() int64 {
+	return c.MaxClicks
+}
+
+// GetRules ...
+func (c *CreateURLRequest) GetRules() []*Rule {
+	return c.Rules
+}
+
+// GetVariants ...
+func (c *CreateURLRequest) GetVariants() []*Variant {
+	return c.Variants
+}
+
+// GetPassthrough ...
+func (c *CreateURLRequest) GetPassthrough() bool {
+	return c.Passthrough
+}
+
+// GetUtm ...
+func (c *CreateURLRequest) GetUtm() *UTM {
+	return c.UTM
+}
+
 // BulkCreateURLRequest ...
 type BulkCreateURLRequest struct {
-	CorrelationID string `json:"correlation_id"`
-	OriginalURL   string `json:"original_url"`
+	CorrelationID string     `json:"correlation_id"`
+	OriginalURL   string     `json:"original_url"`
+	ExpiresAt     string     `json:"expires_at,omitempty"`
+	TTL           int64      `json:"ttl,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	RedirectCode  uint32     `json:"redirect_code,omitempty"`
+	Password      string     `json:"password,omitempty"`
+	MaxClicks     int64      `json:"max_clicks,omitempty"`
+	Rules         []*Rule    `json:"rules,omitempty"`
+	Variants      []*Variant `json:"variants,omitempty"`
+	Passthrough   bool       `json:"passthrough,omitempty"`
+	UTM           *UTM       `json:"utm,omitempty"`
 }
 
 func (b *BulkCreateURLRequest) GetCorrelationId() string {
@@ -13,3 +108,146 @@ func (b *BulkCreateURLRequest) GetCorrelationId() string {
 func (b *BulkCreateURLRequest) GetOriginalUrl() string {
 	return b.OriginalURL
 }
+
+// GetExpiresAt ...
+func (b *BulkCreateURLRequest) GetExpiresAt() string {
+	return b.ExpiresAt
+}
+
+// GetTtl ...
+func (b *BulkCreateURLRequest) GetTtl() int64 {
+	return b.TTL
+}
+
+// GetTitle ...
+func (b *BulkCreateURLRequest) GetTitle() string {
+	return b.Title
+}
+
+// GetNote ...
+func (b *BulkCreateURLRequest) GetNote() string {
+	return b.Note
+}
+
+// GetTags ...
+func (b *BulkCreateURLRequest) GetTags() []string {
+	return b.Tags
+}
+
+// GetRedirectCode ...
+func (b *BulkCreateURLRequest) GetRedirectCode() uint32 {
+	return b.RedirectCode
+}
+
+// GetPassword ...
+func (b *BulkCreateURLRequest) GetPassword() string {
+	return b.Password
+}
+
+// GetMaxClicks ...
+func (b *BulkCreateURLRequest) GetMaxClicks() int64 {
+	return b.MaxClicks
+}
+
+// GetRules ...
+func (b *BulkCreateURLRequest) GetRules() []*Rule {
+	return b.Rules
+}
+
+// GetVariants ...
+func (b *BulkCreateURLRequest) GetVariants() []*Variant {
+	return b.Variants
+}
+
+// GetPassthrough ...
+func (b *BulkCreateURLRequest) GetPassthrough() bool {
+	return b.Passthrough
+}
+
+// GetUtm ...
+func (b *BulkCreateURLRequest) GetUtm() *UTM {
+	return b.UTM
+}
+
+// UpdateURLRequest is request of url change. Fields which are not provided are not changed.
+type UpdateURLRequest struct {
+	URL          string `json:"url,omitempty"`
+	RedirectCode uint32 `json:"redirect_code,omitempty"`
+	// Password is new password of url. RemovePassword removes password protection of url.
+	Password       string `json:"password,omitempty"`
+	RemovePassword bool   `json:"remove_password,omitempty"`
+	// MaxClicks is new limit of clicks of url. Clicks which are already done are counted in it.
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+	// Rules replace targeting rules of url. ClearRules removes all targeting rules of url.
+	Rules      []*Rule `json:"rules,omitempty"`
+	ClearRules bool    `json:"clear_rules,omitempty"`
+	// Variants replace split variants of url. ClearVariants removes all split variants of url.
+	Variants      []*Variant `json:"variants,omitempty"`
+	ClearVariants bool       `json:"clear_variants,omitempty"`
+	// Passthrough enables forwarding of query to destination of url. DisablePassthrough disables it.
+	Passthrough        bool `json:"passthrough,omitempty"`
+	DisablePassthrough bool `json:"disable_passthrough,omitempty"`
+	// UTM replaces utm template of url. ClearUTM removes utm template of url.
+	UTM      *UTM `json:"utm,omitempty"`
+	ClearUTM bool `json:"clear_utm,omitempty"`
+}
+
+// GetRedirectCode ...
+func (u *UpdateURLRequest) GetRedirectCode() uint32 {
+	return u.RedirectCode
+}
+
+// GetPassword ...
+func (u *UpdateURLRequest) GetPassword() string {
+	return u.Password
+}
+
+// GetRemovePassword ...
+func (u *UpdateURLRequest) GetRemovePassword() bool {
+	return u.RemovePassword
+}
+
+// GetMaxClicks ...
+func (u *UpdateURLRequest) GetMaxClicks() int64 {
+	return u.MaxClicks
+}
+
+// GetRules ...
+func (u *UpdateURLRequest) GetRules() []*Rule {
+	return u.Rules
+}
+
+// GetClearRules ...
+func (u *UpdateURLRequest) GetClearRules() bool {
+	return u.ClearRules
+}
+
+// GetVariants ...
+func (u *UpdateURLRequest) GetVariants() []*Variant {
+	return u.Variants
+}
+
+// GetClearVariants ...
+func (u *UpdateURLRequest) GetClearVariants() bool {
+	return u.ClearVariants
+}
+
+// GetPassthrough ...
+func (u *UpdateURLRequest) GetPassthrough() bool {
+	return u.Passthrough
+}
+
+// GetDisablePassthrough ...
+func (u *UpdateURLRequest) GetDisablePassthrough() bool {
+	return u.DisablePassthrough
+}
+
+// GetUtm ...
+func (u *UpdateURLRequest) GetUtm() *UTM {
+	return u.UTM
+}
+
+// GetClearUtm ...
+func (u *UpdateURLRequest) GetClearUtm() bool {
+	return u.ClearUTM
+}
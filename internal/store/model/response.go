@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // types ...
 type (
 	// ResultResponse ...
@@ -10,7 +12,32 @@ type (
 	// AllUserURLsResponse ...
 	AllUserURLsResponse struct {
 		OriginalURL string `json:"original_url"`
-		ShortURL    string `json:"short_url"`
+		// CanonicalURL is canonical form of original url which is used for redirect. It is provided only if
+		// it differs from original url.
+		CanonicalURL string   `json:"canonical_url,omitempty"`
+		ShortURL     string   `json:"short_url"`
+		Title        string   `json:"title,omitempty"`
+		Note         string   `json:"note,omitempty"`
+		Tags         []string `json:"tags,omitempty"`
+		// RedirectCode is http status code of redirect to original url.
+		RedirectCode int `json:"redirect_code"`
+		// Protected reports whether url is protected with password.
+		Protected bool `json:"protected"`
+		// MaxClicks is limit of clicks of url and ClicksLeft is remaining count of clicks. They are provided
+		// only for urls with limited clicks.
+		MaxClicks  int64  `json:"max_clicks,omitempty"`
+		ClicksLeft *int64 `json:"clicks_left,omitempty"`
+		// Rules are targeting rules of url.
+		Rules []*Rule `json:"rules,omitempty"`
+		// Variants are split variants of url.
+		Variants []*Variant `json:"variants,omitempty"`
+		// Passthrough reports whether query of short url is forwarded to destination.
+		Passthrough bool `json:"passthrough"`
+		// UTM is utm template of url.
+		UTM       *UTM       `json:"utm,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
 	}
 
 	// BatchCreateURLsResponse ...
@@ -18,4 +45,14 @@ type (
 		ShortURL      string `json:"short_url"`
 		CorrelationID string `json:"correlation_id"`
 	}
+
+	// RestoreURLsResponse ...
+	RestoreURLsResponse struct {
+		Restored int64 `json:"restored"`
+	}
+
+	// PurgeURLsResponse ...
+	PurgeURLsResponse struct {
+		Purged int64 `json:"purged"`
+	}
 )
@@ -0,0 +1,243 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// devices which are detected by user agent of visitor.
+const (
+	// DeviceIOS ...
+	DeviceIOS = "ios"
+	// DeviceAndroid ...
+	DeviceAndroid = "android"
+	// DeviceMobile is any mobile device, including iOS and Android ones.
+	DeviceMobile = "mobile"
+	// DeviceDesktop ...
+	DeviceDesktop = "desktop"
+
+	// MaxRules is max count of targeting rules of one url.
+	MaxRules = 20
+)
+
+// vars ...
+var (
+	// ErrRuleBad ...
+	ErrRuleBad = errors.New("bad targeting rule")
+	// ErrTooManyRules ...
+	ErrTooManyRules = errors.New("url may have at most 20 targeting rules")
+)
+
+// types ...
+type (
+	// Rule is targeting rule of url. Visitor which matches all conditions of rule is redirected to destination
+	// of rule instead of original url. Empty condition matches any visitor, but rule must have at least one
+	// condition.
+	Rule struct {
+		// Device is one of DeviceIOS, DeviceAndroid, DeviceMobile and DeviceDesktop.
+		Device string `json:"device,omitempty"`
+		// Languages are language tags of rule. Visitor matches rule if any language of its Accept-Language
+		// header is one of them or is subtag of one of them: rule with "en" matches visitor with "en-US".
+		Languages []string `json:"languages,omitempty"`
+		// From and To are time window of rule: visitor matches rule at or after From and before To.
+		From *time.Time `json:"from,omitempty"`
+		To   *time.Time `json:"to,omitempty"`
+		// Destination is url where matching visitor is redirected to.
+		Destination string `json:"destination"`
+	}
+
+	// Visitor is client which opens url.
+	Visitor struct {
+		UserAgent      string
+		AcceptLanguage string
+		Time           time.Time
+		// Variant is name of split variant which was chosen for visitor before.
+		Variant string
+	}
+)
+
+// WithRules sets targeting rules of url in provided order. Empty rules are ignored.
+func WithRules(rules []*Rule) URLOption {
+	return func(u *URL) error {
+		if len(rules) == 0 {
+			return nil
+		}
+		if len(rules) > MaxRules {
+			return ErrTooManyRules
+		}
+		res := make([]*Rule, 0, len(rules))
+		for i, r := range rules {
+			if r == nil {
+				return fmt.Errorf("%w %d: rule is empty", ErrRuleBad, i)
+			}
+			normalized, err := r.normalize()
+			if err != nil {
+				return fmt.Errorf("%w %d: %v", ErrRuleBad, i, err)
+			}
+			res = append(res, normalized)
+		}
+		u.Rules = res
+		return nil
+	}
+}
+
+// WithoutRules removes all targeting rules of url.
+func WithoutRules() URLOption {
+	return func(u *URL) error {
+		u.Rules = nil
+		return nil
+	}
+}
+
+// normalize returns validated copy of rule with languages in lower case and times in UTC.
+func (r *Rule) normalize() (*Rule, error) {
+	if err := CheckFormat(r.Destination); err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+	res := &Rule{
+		Device:      strings.ToLower(strings.TrimSpace(r.Device)),
+		Destination: r.Destination,
+	}
+	switch res.Device {
+	case "", DeviceIOS, DeviceAndroid, DeviceMobile, DeviceDesktop:
+	default:
+		return nil, fmt.Errorf("unknown device %q", r.Device)
+	}
+	for _, lang := range r.Languages {
+		normalized := normalizeLanguage(lang)
+		if !isLanguageTag(normalized) {
+			return nil, fmt.Errorf("bad language %q", lang)
+		}
+		res.Languages = append(res.Languages, normalized)
+	}
+	if r.From != nil {
+		from := r.From.UTC()
+		res.From = &from
+	}
+	if r.To != nil {
+		to := r.To.UTC()
+		res.To = &to
+	}
+	if res.From != nil && res.To != nil && !res.From.Before(*res.To) {
+		return nil, errors.New("from must be before to")
+	}
+	if res.Device == "" && len(res.Languages) == 0 && res.From == nil && res.To == nil {
+		return nil, errors.New("rule must have at least one condition")
+	}
+	return res, nil
+}
+
+// normalizeLanguage returns language tag in lower case with '-' as separator of subtags.
+func normalizeLanguage(lang string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(lang)), "_", "-")
+}
+
+// isLanguageTag reports whether lang is normalized language tag: primary subtag of 2-8 letters and
+// optional subtags of 1-8 letters or digits.
+func isLanguageTag(lang string) bool {
+	for i, sub := range strings.Split(lang, "-") {
+		if len(sub) == 0 || len(sub) > 8 || i == 0 && len(sub) < 2 {
+			return false
+		}
+		for _, r := range sub {
+			switch {
+			case r >= 'a' && r <= 'z':
+			case r >= '0' && r <= '9' && i > 0:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Device returns device of visitor which is detected by user agent.
+func (v *Visitor) Device() string {
+	switch ua := v.UserAgent; {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iPod"):
+		return DeviceIOS
+	case strings.Contains(ua, "Android"):
+		return DeviceAndroid
+	case strings.Contains(ua, "Mobile"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// Languages returns languages of Accept-Language header of visitor. Languages with zero quality and
+// wildcard are skipped.
+func (v *Visitor) Languages() (langs []string) {
+	for _, part := range strings.Split(v.AcceptLanguage, ",") {
+		lang, params, _ := strings.Cut(part, ";")
+		lang = normalizeLanguage(lang)
+		if lang == "" || lang == "*" {
+			continue
+		}
+		if q := strings.TrimSpace(params); strings.HasPrefix(q, "q=0") && strings.Trim(q[2:], "0.") == "" {
+			continue
+		}
+		langs = append(langs, lang)
+	}
+	return langs
+}
+
+// match reports whether visitor with provided device and languages matches rule.
+func (r *Rule) match(v *Visitor, device string, langs []string) bool {
+	switch {
+	case r.Device == DeviceMobile && device == DeviceDesktop:
+		return false
+	case r.Device != "" && r.Device != DeviceMobile && r.Device != device:
+		return false
+	case r.From != nil && v.Time.Before(*r.From):
+		return false
+	case r.To != nil && !v.Time.Before(*r.To):
+		return false
+	}
+	if len(r.Languages) == 0 {
+		return true
+	}
+	for _, lang := range langs {
+		for _, ruleLang := range r.Languages {
+			if lang == ruleLang || strings.HasPrefix(lang, ruleLang+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Target returns destination of url for visitor and split variant which is chosen for visitor. Destination
+// of first rule which matches visitor is returned without variant. Otherwise destination of variant is
+// returned if url has variants, or original url if it hasn't.
+func (u *URL) Target(v *Visitor) (string, *Variant) {
+	if len(u.Rules) != 0 {
+		device, langs := v.Device(), v.Languages()
+		for _, r := range u.Rules {
+			if r.match(v, device, langs) {
+				return r.Destination, nil
+			}
+		}
+	}
+	if len(u.Variants) == 0 {
+		return u.BaseURL, nil
+	}
+	variant := u.chooseVariant(v)
+	return variant.Destination, variant
+}
+
+// Destinations returns all urls where visitors of url may be redirected to: original url and destinations
+// of rules and variants.
+func (u *URL) Destinations() []string {
+	dest := make([]string, 0, 1+len(u.Rules)+len(u.Variants))
+	dest = append(dest, u.BaseURL)
+	for _, r := range u.Rules {
+		dest = append(dest, r.Destination)
+	}
+	for _, v := range u.Variants {
+		dest = append(dest, v.Destination)
+	}
+	return dest
+}
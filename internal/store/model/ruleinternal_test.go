@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	iPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+	androidUA = "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 Chrome/108.0 Mobile Safari/537.36"
+	desktopUA = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 Chrome/108.0 Safari/537.36"
+)
+
+func TestWithRules(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	tt := []struct {
+		name  string
+		rules []*Rule
+		err   error
+	}{
+		{name: "device", rules: []*Rule{{Device: "IOS", Destination: "https://apps.apple.com"}}},
+		{name: "language", rules: []*Rule{{Languages: []string{"en_US", "de"}, Destination: "https://example.com"}}},
+		{name: "time window", rules: []*Rule{{From: &from, To: &to, Destination: "https://example.com"}}},
+		{name: "no conditions", rules: []*Rule{{Destination: "https://example.com"}}, err: ErrRuleBad},
+		{name: "nil rule", rules: []*Rule{nil}, err: ErrRuleBad},
+		{name: "bad destination", rules: []*Rule{{Device: DeviceIOS, Destination: "bad url"}}, err: ErrRuleBad},
+		{name: "unknown device", rules: []*Rule{{Device: "tv", Destination: "https://example.com"}}, err: ErrRuleBad},
+		{name: "bad language", rules: []*Rule{{Languages: []string{"e"}, Destination: "https://example.com"}}, err: ErrRuleBad},
+		{name: "empty window", rules: []*Rule{{From: &to, To: &from, Destination: "https://example.com"}}, err: ErrRuleBad},
+		{name: "too many rules", rules: make([]*Rule, MaxRules+1), err: ErrTooManyRules},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := NewURL("https://example.org", "marlo")
+			require.NoError(t, err)
+			err = u.Apply(WithRules(tc.rules))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, u.Rules)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, u.Rules, len(tc.rules))
+		})
+	}
+
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	rules := []*Rule{{Device: "IOS", Languages: []string{"en_US"}, Destination: "https://example.com"}}
+	require.NoError(t, u.Apply(WithRules(rules)))
+	assert.Equal(t, &Rule{Device: DeviceIOS, Languages: []string{"en-us"}, Destination: "https://example.com"}, u.Rules[0])
+	assert.Equal(t, "IOS", rules[0].Device, "rules of request must not be changed")
+	require.NoError(t, u.Apply(OptionsOf(&UpdateURLRequest{ClearRules: true})...))
+	assert.Nil(t, u.Rules)
+}
+
+func TestURL_Target(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(WithRules([]*Rule{
+		{Device: DeviceIOS, Destination: "https://apps.apple.com"},
+		{Device: DeviceAndroid, Destination: "https://play.google.com"},
+		{Languages: []string{"de"}, From: &start, To: &end, Destination: "https://example.org/de/sale"},
+		{Languages: []string{"de"}, Destination: "https://example.org/de"},
+		{Device: DeviceMobile, Destination: "https://m.example.org"},
+	})))
+
+	tt := []struct {
+		name    string
+		visitor Visitor
+		want    string
+	}{
+		{name: "ios", visitor: Visitor{UserAgent: iPhoneUA, AcceptLanguage: "de"}, want: "https://apps.apple.com"},
+		{name: "android", visitor: Visitor{UserAgent: androidUA}, want: "https://play.google.com"},
+		{name: "other mobile", visitor: Visitor{UserAgent: "Opera Mini Mobile"}, want: "https://m.example.org"},
+		{name: "desktop", visitor: Visitor{UserAgent: desktopUA, AcceptLanguage: "en-US,en;q=0.9"}, want: "https://example.org"},
+		{
+			name:    "language in window",
+			visitor: Visitor{UserAgent: desktopUA, AcceptLanguage: "en;q=0.8, de-AT", Time: start.Add(time.Hour)},
+			want:    "https://example.org/de/sale",
+		},
+		{
+			name:    "language out of window",
+			visitor: Visitor{UserAgent: desktopUA, AcceptLanguage: "de-DE", Time: end},
+			want:    "https://example.org/de",
+		},
+		{
+			name:    "not acceptable language",
+			visitor: Visitor{UserAgent: desktopUA, AcceptLanguage: "en, de;q=0.0"},
+			want:    "https://example.org",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			target, variant := u.Target(&tc.visitor)
+			assert.Equal(t, tc.want, target)
+			assert.Nil(t, variant)
+		})
+	}
+}
+
+func TestURL_Destinations(t *testing.T) {
+	u := &URL{
+		BaseURL:  "https://example.org",
+		Rules:    []*Rule{{Device: DeviceIOS, Destination: "https://apps.apple.com"}},
+		Variants: []*Variant{{Name: "b", Destination: "https://example.org/b", Weight: 1}},
+	}
+	assert.Equal(t, []string{"https://example.org", "https://apps.apple.com", "https://example.org/b"}, u.Destinations())
+}
@@ -1,10 +1,38 @@
 package model
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// const ...
+const (
+	// AliasMinLength ...
+	AliasMinLength = 3
+	// AliasMaxLength ...
+	AliasMaxLength = 64
+	// TitleMaxLength ...
+	TitleMaxLength = 256
+	// NoteMaxLength ...
+	NoteMaxLength = 1024
+	// TagMaxLength ...
+	TagMaxLength = 32
+	// MaxTags is max count of tags of one url.
+	MaxTags = 20
+	// PasswordMaxLength is max length of password in bytes. Longer passwords are not supported by bcrypt.
+	PasswordMaxLength = 72
+	// DefaultRedirectCode is http status code of redirect of urls which have no redirect code.
+	DefaultRedirectCode = http.StatusTemporaryRedirect
 )
 
 // vars ...
@@ -15,17 +43,112 @@ var (
 	ErrURLTooShort = errors.New("url must be 4 or more chars long")
 	// ErrURLBadCorrelationID ...
 	ErrURLBadCorrelationID = errors.New("correlation ID must be one")
+	// ErrAliasBadLength ...
+	ErrAliasBadLength = errors.New("alias must be from 3 to 64 chars long")
+	// ErrAliasBadCharset ...
+	ErrAliasBadCharset = errors.New("alias may contain only latin letters, digits, '-' and '_'")
+	// ErrAliasReserved ...
+	ErrAliasReserved = errors.New("alias is reserved")
+	// ErrExpirationAmbiguous ...
+	ErrExpirationAmbiguous = errors.New("only one of expires_at and ttl may be provided")
+	// ErrExpirationBadFormat ...
+	ErrExpirationBadFormat = errors.New("expires_at must be in RFC 3339 format")
+	// ErrExpirationInPast ...
+	ErrExpirationInPast = errors.New("expiration time must be in future")
+	// ErrTitleTooLong ...
+	ErrTitleTooLong = errors.New("title must be at most 256 chars long")
+	// ErrNoteTooLong ...
+	ErrNoteTooLong = errors.New("note must be at most 1024 chars long")
+	// ErrTagBad ...
+	ErrTagBad = errors.New("tag must be from 1 to 32 chars long and may contain only latin letters, digits, '-' and '_'")
+	// ErrTooManyTags ...
+	ErrTooManyTags = errors.New("url may have at most 20 tags")
+	// ErrPasswordTooLong ...
+	ErrPasswordTooLong = errors.New("password must be at most 72 bytes long")
+	// ErrMaxClicksNegative ...
+	ErrMaxClicksNegative = errors.New("max clicks must not be negative")
+	// ErrRedirectCodeBad ...
+	ErrRedirectCodeBad = errors.New("redirect code must be one of 301, 302, 307 and 308")
+
+	// ReservedAliases are first segments of server paths which can't be used as short ids.
+	ReservedAliases = []string{"api", "ping", "debug"}
+	// RedirectCodes are http status codes which may be used as redirect code of url.
+	RedirectCodes = []int{
+		http.StatusMovedPermanently,
+		http.StatusFound,
+		http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect,
+	}
 )
 
 // URL ...
 type URL struct {
-	BaseURL   string `json:"url"`
-	User      string `json:"user,omitempty"`
-	CorelID   string `json:"-"`
-	ID        string `json:"result,omitempty"`
-	IsDeleted bool   `json:"-"`
+	// BaseURL is original url which is used for redirect and deduplication.
+	BaseURL string `json:"url"`
+	// RawURL is original url as it was provided by user if it differs from its canonical form in BaseURL.
+	RawURL    string     `json:"raw_url,omitempty"`
+	User      string     `json:"user,omitempty"`
+	CorelID   string     `json:"-"`
+	ID        string     `json:"result,omitempty"`
+	Alias     string     `json:"alias,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Title     string     `json:"title,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	// RedirectCode is http status code of redirect to original url. Zero means DefaultRedirectCode.
+	RedirectCode int `json:"redirect_code,omitempty"`
+	// PasswordHash is bcrypt hash of password which is required to open url. Empty hash means that url
+	// isn't protected.
+	PasswordHash string `json:"password_hash,omitempty"`
+	// MaxClicks is count of clicks after which url is exhausted. Zero means that clicks aren't limited.
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+	// ClicksLeft is remaining count of clicks of url with limited clicks.
+	ClicksLeft int64 `json:"clicks_left,omitempty"`
+	// Rules are targeting rules of url which are checked in order on redirect.
+	Rules []*Rule `json:"rules,omitempty"`
+	// Variants are split variants of url which replace original url for visitors which match no rule.
+	Variants []*Variant `json:"variants,omitempty"`
+	// Passthrough enables forwarding of query of short url to destination.
+	Passthrough bool `json:"passthrough,omitempty"`
+	// UTM is template of utm parameters which are added to destination.
+	UTM       *UTM       `json:"utm,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	IsDeleted bool       `json:"-"`
+	DeletedAt *time.Time `json:"-"`
+	// IsSwept reports whether expired url was swept by store. Expiration is state of its own: swept url is
+	// not deleted, so it is never restored and isn't purged with deleted urls.
+	IsSwept bool `json:"-"`
+
+	// gen generates id of url. It is kept in url, so stores are able to regenerate id on collision.
+	gen IDGenerator
+}
+
+// Destination is previous original url of url which was replaced by new one at ReplacedAt.
+type Destination struct {
+	OriginalURL string    `json:"original_url"`
+	ReplacedAt  time.Time `json:"replaced_at"`
 }
 
+// IDGenerator generates short ids of urls.
+type IDGenerator interface {
+	GenerateID(ctx context.Context) (string, error)
+}
+
+// IDGeneratorFunc ...
+type IDGeneratorFunc func(ctx context.Context) (string, error)
+
+// GenerateID ...
+func (f IDGeneratorFunc) GenerateID(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// DefaultIDGenerator is used by urls which are created without generator.
+var DefaultIDGenerator IDGenerator = IDGeneratorFunc(randomHexID)
+
+// URLOption is optional setting of URL which is applied after url creation.
+type URLOption func(u *URL) error
+
 type URLer interface {
 	GetCorrelationId() string
 	GetOriginalUrl() string
@@ -33,45 +156,434 @@ type URLer interface {
 
 // NewURL ...
 func NewURL(url, user string, correlationID ...string) (*URL, error) {
+	return NewURLWithGenerator(context.Background(), nil, url, user, correlationID...)
+}
+
+// NewURLWithGenerator creates url with id generated by gen. If gen is nil, DefaultIDGenerator is used.
+func NewURLWithGenerator(ctx context.Context, gen IDGenerator, url, user string, correlationID ...string) (*URL, error) {
 	u := &URL{
 		BaseURL:   url,
 		User:      user,
 		IsDeleted: false,
+		gen:       gen,
 	}
+	u.CreatedAt = Now()
+	u.UpdatedAt = u.CreatedAt
 	if len(correlationID) > 1 {
 		return nil, ErrURLBadCorrelationID
 	} else if len(correlationID) == 1 {
 		u.CorelID = correlationID[0]
 	}
-	if err := u.ShortURL(); err != nil {
+	if err := u.ShortURL(ctx); err != nil {
 		return nil, err
 	}
 	return u, nil
 }
 
-// Validate ...
-func (u *URL) Validate() error {
-	if strings.Contains(u.BaseURL, " ") {
-		return ErrURLContainSpace
+// WithAlias sets user provided alias as short id of url. Empty alias is ignored.
+func WithAlias(alias string) URLOption {
+	return func(u *URL) error {
+		if alias == "" {
+			return nil
+		}
+		if err := ValidateAlias(alias); err != nil {
+			return err
+		}
+		u.Alias = alias
+		u.ID = alias
+		return nil
+	}
+}
+
+// WithExpiration sets expiration time of url. expiresAt must be in RFC 3339 format, ttl is count of seconds
+// since now. Only one of them may be provided; empty expiresAt and zero ttl are ignored.
+func WithExpiration(expiresAt string, ttl int64) URLOption {
+	return func(u *URL) error {
+		var t time.Time
+		switch {
+		case expiresAt != "" && ttl != 0:
+			return ErrExpirationAmbiguous
+		case expiresAt != "":
+			var err error
+			if t, err = time.Parse(time.RFC3339, expiresAt); err != nil {
+				return ErrExpirationBadFormat
+			}
+		case ttl != 0:
+			t = time.Now().Add(time.Duration(ttl) * time.Second)
+		default:
+			return nil
+		}
+		t = t.UTC()
+		if !t.After(time.Now()) {
+			return ErrExpirationInPast
+		}
+		u.ExpiresAt = &t
+		return nil
+	}
+}
+
+// WithMetadata sets title, note and tags of url. Tags are normalized with NormalizeTag, duplicates of tags
+// are removed.
+func WithMetadata(title, note string, tags []string) URLOption {
+	return func(u *URL) error {
+		if utf8.RuneCountInString(title) > TitleMaxLength {
+			return ErrTitleTooLong
+		}
+		if utf8.RuneCountInString(note) > NoteMaxLength {
+			return ErrNoteTooLong
+		}
+		normalized, err := NormalizeTags(tags)
+		if err != nil {
+			return err
+		}
+		u.Title = strings.TrimSpace(title)
+		u.Note = note
+		u.Tags = normalized
+		return nil
+	}
+}
+
+// WithRedirectCode sets http status code of redirect to original url. Zero code is ignored.
+func WithRedirectCode(code uint32) URLOption {
+	return func(u *URL) error {
+		if code == 0 {
+			return nil
+		}
+		for _, c := range RedirectCodes {
+			if int(code) == c {
+				u.RedirectCode = c
+				return nil
+			}
+		}
+		return ErrRedirectCodeBad
+	}
+}
+
+// Redirect returns http status code of redirect to original url.
+func (u *URL) Redirect() int {
+	if u.RedirectCode == 0 {
+		return DefaultRedirectCode
+	}
+	return u.RedirectCode
+}
+
+// WithPassword protects url with password. Only bcrypt hash of password is kept. Empty password is ignored.
+func WithPassword(password string) URLOption {
+	return func(u *URL) error {
+		if password == "" {
+			return nil
+		}
+		if len(password) > PasswordMaxLength {
+			return ErrPasswordTooLong
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("hash password: %w", err)
+		}
+		u.PasswordHash = string(hash)
+		return nil
+	}
+}
+
+// WithoutPassword removes password protection of url.
+func WithoutPassword() URLOption {
+	return func(u *URL) error {
+		u.PasswordHash = ""
+		return nil
+	}
+}
+
+// IsProtected reports whether url is protected with password.
+func (u *URL) IsProtected() bool {
+	return u.PasswordHash != ""
+}
+
+// CheckPassword reports whether password of protected url is correct.
+func (u *URL) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// WithMaxClicks limits count of clicks of url. Clicks which are already done are counted in new limit, so
+// url may be exhausted at once. Zero count is ignored.
+func WithMaxClicks(n int64) URLOption {
+	return func(u *URL) error {
+		switch {
+		case n < 0:
+			return ErrMaxClicksNegative
+		case n == 0:
+			return nil
+		}
+		used := u.MaxClicks - u.ClicksLeft
+		u.MaxClicks = n
+		u.ClicksLeft = n - used
+		if u.ClicksLeft < 0 {
+			u.ClicksLeft = 0
+		}
+		return nil
+	}
+}
+
+// IsLimited reports whether clicks of url are limited.
+func (u *URL) IsLimited() bool {
+	return u.MaxClicks > 0
+}
+
+// IsExhausted reports whether all clicks of url with limited clicks are done.
+func (u *URL) IsExhausted() bool {
+	return u.IsLimited() && u.ClicksLeft <= 0
+}
+
+// IsStatic reports whether url redirects every visitor to its original url as is at any time: it has no
+// rules, variants, query settings, password, expiration or clicks limit.
+func (u *URL) IsStatic() bool {
+	return len(u.Rules) == 0 && len(u.Variants) == 0 && !u.Passthrough && u.UTM == nil &&
+		!u.IsProtected() && u.ExpiresAt == nil && !u.IsLimited()
+}
+
+// NormalizeTag returns tag in lower case without surrounding spaces or error if tag is not valid.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || len(tag) > TagMaxLength {
+		return "", ErrTagBad
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return "", ErrTagBad
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeTags returns sorted set of normalized tags. Nil is returned for empty tags.
+func NormalizeTags(tags []string) ([]string, error) {
+	set := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", tag, err)
+		}
+		set[normalized] = struct{}{}
+	}
+	if len(set) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	if len(set) == 0 {
+		return nil, nil
+	}
+	res := make([]string, 0, len(set))
+	for tag := range set {
+		res = append(res, tag)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// HasTag reports whether url is tagged with tag.
+func (u *URL) HasTag(tag string) bool {
+	for _, t := range u.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// OptionsOf returns options which are provided by getters of v. It allows to use request
+// objects of any transport (json, grpc) for url creation.
+func OptionsOf(v interface{}) (opts []URLOption) {
+	if a, ok := v.(interface{ GetAlias() string }); ok {
+		opts = append(opts, WithAlias(a.GetAlias()))
+	}
+	if e, ok := v.(interface {
+		GetExpiresAt() string
+		GetTtl() int64
+	}); ok {
+		opts = append(opts, WithExpiration(e.GetExpiresAt(), e.GetTtl()))
+	}
+	if m, ok := v.(interface {
+		GetTitle() string
+		GetNote() string
+		GetTags() []string
+	}); ok {
+		opts = append(opts, WithMetadata(m.GetTitle(), m.GetNote(), m.GetTags()))
+	}
+	if r, ok := v.(interface{ GetRedirectCode() uint32 }); ok && r.GetRedirectCode() != 0 {
+		// zero code means that code isn't provided, so settings of url are not changed by requests without it.
+		opts = append(opts, WithRedirectCode(r.GetRedirectCode()))
+	}
+	if m, ok := v.(interface{ GetMaxClicks() int64 }); ok && m.GetMaxClicks() != 0 {
+		opts = append(opts, WithMaxClicks(m.GetMaxClicks()))
+	}
+	if r, ok := v.(interface{ GetClearRules() bool }); ok && r.GetClearRules() {
+		opts = append(opts, WithoutRules())
+	}
+	if r, ok := v.(interface{ GetRules() []*Rule }); ok && len(r.GetRules()) != 0 {
+		opts = append(opts, WithRules(r.GetRules()))
+	}
+	if r, ok := v.(interface{ GetClearVariants() bool }); ok && r.GetClearVariants() {
+		opts = append(opts, WithoutVariants())
+	}
+	if r, ok := v.(interface{ GetVariants() []*Variant }); ok && len(r.GetVariants()) != 0 {
+		opts = append(opts, WithVariants(r.GetVariants()))
+	}
+	if p, ok := v.(interface{ GetDisablePassthrough() bool }); ok && p.GetDisablePassthrough() {
+		opts = append(opts, WithoutPassthrough())
+	}
+	if p, ok := v.(interface{ GetPassthrough() bool }); ok && p.GetPassthrough() {
+		opts = append(opts, WithPassthrough())
+	}
+	if t, ok := v.(interface{ GetClearUtm() bool }); ok && t.GetClearUtm() {
+		opts = append(opts, WithoutUTM())
+	}
+	if t, ok := v.(interface{ GetUtm() *UTM }); ok && t.GetUtm() != nil {
+		opts = append(opts, WithUTM(t.GetUtm()))
+	}
+	if r, ok := v.(interface{ GetRemovePassword() bool }); ok && r.GetRemovePassword() {
+		opts = append(opts, WithoutPassword())
+	}
+	if p, ok := v.(interface{ GetPassword() string }); ok && p.GetPassword() != "" {
+		opts = append(opts, WithPassword(p.GetPassword()))
+	}
+	return
+}
+
+// Apply applies all options to url.
+func (u *URL) Apply(opts ...URLOption) error {
+	for _, opt := range opts {
+		if err := opt(u); err != nil {
+			return err
+		}
 	}
-	if len(u.BaseURL) < 4 {
-		return ErrURLTooShort
+	return nil
+}
+
+// Validate checks original url of url and destinations of its rules and variants by policy.
+func (u *URL) Validate(p URLPolicy) error {
+	for _, dest := range u.Destinations() {
+		if err := p.Check(dest); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// ShortURL ...
-func (u *URL) ShortURL() error {
-	b := make([]byte, 8)
-	_, err := rand.Read(b)
+// ValidateAlias checks that alias can be used as short id.
+func ValidateAlias(alias string) error {
+	if len(alias) < AliasMinLength || len(alias) > AliasMaxLength {
+		return ErrAliasBadLength
+	}
+	for _, r := range alias {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return ErrAliasBadCharset
+		}
+	}
+	if IsReservedID(alias) {
+		return ErrAliasReserved
+	}
+	return nil
+}
+
+// IsReservedID reports whether id is one of ReservedAliases, so url with it can't be opened.
+func IsReservedID(id string) bool {
+	for _, reserved := range ReservedAliases {
+		if strings.EqualFold(id, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// Now returns current time in UTC truncated to microseconds, which is precision of time in postgres,
+// so time of url is same in all stores.
+func Now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// IsExpired returns true if url has expiration time and it is already passed.
+func (u *URL) IsExpired() bool {
+	return u.ExpiresAt != nil && !u.ExpiresAt.After(time.Now())
+}
+
+// MarkSwept marks expired url as swept at provided time.
+func (u *URL) MarkSwept(at time.Time) {
+	u.IsSwept = true
+	u.UpdatedAt = at
+}
+
+// MarkDeleted marks url as deleted at provided time.
+func (u *URL) MarkDeleted(at time.Time) {
+	u.IsDeleted = true
+	u.DeletedAt = &at
+	u.UpdatedAt = at
+}
+
+// Restore removes deletion mark of url at provided time.
+func (u *URL) Restore(at time.Time) {
+	u.IsDeleted = false
+	u.DeletedAt = nil
+	u.UpdatedAt = at
+}
+
+// DeletedBefore reports whether url was deleted before t. Urls which were deleted before deletion time
+// was recorded are considered deleted before any time.
+func (u *URL) DeletedBefore(t time.Time) bool {
+	return u.IsDeleted && (u.DeletedAt == nil || u.DeletedAt.Before(t))
+}
+
+// WithDestination replaces original url of url. Raw is original url as it was provided by user; it is kept
+// for display if it differs from url. Raw url of previous original url is dropped. Url isn't changed if new
+// url is same as current one.
+func WithDestination(url, raw string) URLOption {
+	return func(u *URL) error {
+		if err := CheckFormat(url); err != nil {
+			return err
+		}
+		if url == u.BaseURL {
+			return nil
+		}
+		u.BaseURL = url
+		u.RawURL = ""
+		if raw != url {
+			u.RawURL = raw
+		}
+		return nil
+	}
+}
+
+// HasAlias returns true if short id of url was chosen by user and must not be regenerated.
+func (u *URL) HasAlias() bool {
+	return u.Alias != ""
+}
+
+// ShortURL generates new id of url with its generator. Url with alias always keeps alias as id.
+func (u *URL) ShortURL(ctx context.Context) error {
+	if u.HasAlias() {
+		u.ID = u.Alias
+		return CheckFormat(u.BaseURL)
+	}
+	gen := u.gen
+	if gen == nil {
+		gen = DefaultIDGenerator
+	}
+	id, err := gen.GenerateID(ctx)
 	if err != nil {
-		return err
+		return fmt.Errorf("generate id: %w", err)
 	}
-	u.ID = hex.EncodeToString(b)
-	if strings.ContainsAny(u.ID, "/(=)[]{}`*&^%$#@!\\<>|\"") {
-		return u.ShortURL()
+	u.ID = id
+	return CheckFormat(u.BaseURL)
+}
+
+// randomHexID returns 8 random bytes in hex.
+func randomHexID(_ context.Context) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	return u.Validate()
+	return hex.EncodeToString(b), nil
 }
 
 // GetUser ...
@@ -88,3 +600,8 @@ func (u *URL) GetCorrelationId() string {
 func (u *URL) GetOriginalUrl() string {
 	return u.BaseURL
 }
+
+// GetAlias ...
+func (u *URL) GetAlias() string {
+	return u.Alias
+}
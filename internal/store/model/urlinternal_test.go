@@ -1,7 +1,12 @@
 package model
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +17,7 @@ func TestValidate(t *testing.T) {
 		name      string
 		url       string
 		wantError bool
+		err       error
 	}{
 		{
 			name:      "empty url",
@@ -25,28 +31,69 @@ func TestValidate(t *testing.T) {
 		},
 		{
 			name:      "space in url",
-			url:       "yandex. ru",
+			url:       "https://yandex. ru",
 			wantError: true,
+			err:       ErrURLContainSpace,
 		},
 		{
 			name:      "positive case #1",
-			url:       "y.ru",
+			url:       "http://y.ru",
 			wantError: false,
 		},
 		{
 			name:      "positive case #2",
-			url:       "yandex.ru",
+			url:       "HTTPS://yandex.ru/search?text=go#results",
 			wantError: false,
 		},
+		{
+			name:      "no scheme",
+			url:       "yandex.ru",
+			wantError: true,
+			err:       ErrURLBadFormat,
+		},
+		{
+			name:      "javascript",
+			url:       "javascript:alert(1)",
+			wantError: true,
+			err:       ErrURLSchemeNotAllowed,
+		},
+		{
+			name:      "file",
+			url:       "file:///etc/passwd",
+			wantError: true,
+			err:       ErrURLSchemeNotAllowed,
+		},
+		{
+			name:      "no host",
+			url:       "https:///path",
+			wantError: true,
+			err:       ErrURLNoHost,
+		},
+		{
+			name:      "too long",
+			url:       "https://yandex.ru/" + strings.Repeat("a", DefaultURLMaxLength),
+			wantError: true,
+			err:       ErrURLTooLong,
+		},
+		{
+			name:      "bad format",
+			url:       "https://yandex.ru/%zz",
+			wantError: true,
+			err:       ErrURLBadFormat,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			url := URL{
 				BaseURL: tt.url,
 			}
-			err := url.Validate()
+			err := url.Validate(URLPolicy{})
 			if tt.wantError {
 				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+					assert.Equal(t, tt.err, URLError(fmt.Errorf("wrapped: %w", err)))
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -54,6 +101,60 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestURLPolicy_Check(t *testing.T) {
+	p := URLPolicy{Schemes: []string{"https", "ftp"}, MaxLength: 32, RejectPrivateHosts: true}
+	tt := []struct {
+		url string
+		err error
+	}{
+		{url: "https://example.org", err: nil},
+		{url: "ftp://example.org/file", err: nil},
+		{url: "https://8.8.8.8/", err: nil},
+		{url: "http://example.org", err: ErrURLSchemeNotAllowed},
+		{url: "https://example.org/" + strings.Repeat("a", 32), err: ErrURLTooLong},
+		{url: "https://localhost:8080/", err: ErrURLPrivateHost},
+		{url: "https://api.localhost/", err: ErrURLPrivateHost},
+		{url: "https://127.0.0.1/", err: ErrURLPrivateHost},
+		{url: "https://10.0.0.1/", err: ErrURLPrivateHost},
+		{url: "https://192.168.1.1/", err: ErrURLPrivateHost},
+		{url: "https://169.254.169.254/", err: ErrURLPrivateHost},
+		{url: "https://0.0.0.0/", err: ErrURLPrivateHost},
+		{url: "https://[::1]/", err: ErrURLPrivateHost},
+		{url: "https://[::ffff:10.0.0.1]/", err: ErrURLPrivateHost},
+		{url: "https://[fd00::1]/", err: ErrURLPrivateHost},
+	}
+	for _, tc := range tt {
+		t.Run(tc.url, func(t *testing.T) {
+			err := p.Check(tc.url)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	p.RejectPrivateHosts = false
+	assert.NoError(t, p.Check("https://127.0.0.1/"))
+	assert.Nil(t, URLError(ErrAliasReserved))
+}
+
+func TestURL_Validate_Policy(t *testing.T) {
+	ftp := URLPolicy{Schemes: []string{" FTP "}, MaxLength: 24}
+	assert.NoError(t, (&URL{BaseURL: "ftp://example.org"}).Validate(ftp))
+	assert.ErrorIs(t, (&URL{BaseURL: "https://example.org"}).Validate(ftp), ErrURLSchemeNotAllowed)
+	assert.ErrorIs(t, (&URL{BaseURL: "ftp://example.org/long/path"}).Validate(ftp), ErrURLTooLong)
+
+	// zero policy allows http and https urls.
+	assert.NoError(t, (&URL{BaseURL: "https://example.org"}).Validate(URLPolicy{}))
+	assert.ErrorIs(t, (&URL{BaseURL: "ftp://example.org"}).Validate(URLPolicy{}), ErrURLSchemeNotAllowed)
+
+	// destinations of rules are checked by same policy.
+	u := &URL{BaseURL: "https://example.org", Rules: []*Rule{{Device: DeviceIOS, Destination: "ftp://example.org"}}}
+	assert.ErrorIs(t, u.Validate(URLPolicy{}), ErrURLSchemeNotAllowed)
+	assert.NoError(t, CheckFormat("ftp://example.org"), "format doesn't depend on policy")
+}
+
 func TestURL_ShortURL(t *testing.T) {
 	tt := []struct {
 		name string
@@ -95,7 +196,7 @@ func TestURL_ShortURL(t *testing.T) {
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.u.ShortURL()
+			err := tc.u.ShortURL(context.Background())
 			if tc.err == nil {
 				assert.NotEmpty(t, tc.u.ID)
 			}
@@ -172,3 +273,312 @@ func TestNewURL(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAlias(t *testing.T) {
+	tt := []struct {
+		name  string
+		alias string
+		err   error
+	}{
+		{
+			name:  "positive case #1",
+			alias: "spring-sale",
+			err:   nil,
+		},
+		{
+			name:  "positive case #2",
+			alias: "Sale_2023",
+			err:   nil,
+		},
+		{
+			name:  "too short",
+			alias: "ab",
+			err:   ErrAliasBadLength,
+		},
+		{
+			name:  "too long",
+			alias: strings.Repeat("a", AliasMaxLength+1),
+			err:   ErrAliasBadLength,
+		},
+		{
+			name:  "slash in alias",
+			alias: "spring/sale",
+			err:   ErrAliasBadCharset,
+		},
+		{
+			name:  "non latin alias",
+			alias: "распродажа",
+			err:   ErrAliasBadCharset,
+		},
+		{
+			name:  "reserved alias",
+			alias: "api",
+			err:   ErrAliasReserved,
+		},
+		{
+			name:  "reserved alias in upper case",
+			alias: "PING",
+			err:   ErrAliasReserved,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateAlias(tc.alias), tc.err)
+		})
+	}
+}
+
+func TestWithAlias(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+
+	require.NoError(t, u.Apply(WithAlias("")))
+	assert.False(t, u.HasAlias())
+
+	require.NoError(t, u.Apply(WithAlias("example")))
+	assert.True(t, u.HasAlias())
+	assert.Equal(t, "example", u.ID)
+
+	require.NoError(t, u.ShortURL(context.Background()))
+	assert.Equal(t, "example", u.ID, "short url must not regenerate alias")
+
+	assert.ErrorIs(t, u.Apply(WithAlias("debug")), ErrAliasReserved)
+}
+
+func TestWithExpiration(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	tt := []struct {
+		name      string
+		expiresAt string
+		ttl       int64
+		err       error
+		expires   bool
+	}{
+		{
+			name:    "without expiration",
+			err:     nil,
+			expires: false,
+		},
+		{
+			name:      "positive case with expires at",
+			expiresAt: future,
+			err:       nil,
+			expires:   true,
+		},
+		{
+			name:    "positive case with ttl",
+			ttl:     3600,
+			err:     nil,
+			expires: true,
+		},
+		{
+			name:      "both fields provided",
+			expiresAt: future,
+			ttl:       3600,
+			err:       ErrExpirationAmbiguous,
+		},
+		{
+			name:      "bad format",
+			expiresAt: "tomorrow",
+			err:       ErrExpirationBadFormat,
+		},
+		{
+			name:      "expires at in past",
+			expiresAt: past,
+			err:       ErrExpirationInPast,
+		},
+		{
+			name: "negative ttl",
+			ttl:  -1,
+			err:  ErrExpirationInPast,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &URL{BaseURL: "https://example.org"}
+			err := u.Apply(WithExpiration(tc.expiresAt, tc.ttl))
+			require.ErrorIs(t, err, tc.err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expires, u.ExpiresAt != nil)
+			assert.False(t, u.IsExpired())
+		})
+	}
+}
+
+func TestURL_IsExpired(t *testing.T) {
+	past := time.Now().Add(-time.Second)
+	u := &URL{BaseURL: "https://example.org", ExpiresAt: &past}
+	assert.True(t, u.IsExpired())
+}
+
+func TestNewURLWithGenerator(t *testing.T) {
+	ids := []string{"first", "second"}
+	gen := IDGeneratorFunc(func(context.Context) (string, error) {
+		id := ids[0]
+		ids = ids[1:]
+		return id, nil
+	})
+	u, err := NewURLWithGenerator(context.Background(), gen, "https://example.org", "marlo")
+	require.NoError(t, err)
+	assert.Equal(t, "first", u.ID)
+
+	// regeneration uses same generator
+	require.NoError(t, u.ShortURL(context.Background()))
+	assert.Equal(t, "second", u.ID)
+
+	genErr := errors.New("generator is broken")
+	_, err = NewURLWithGenerator(context.Background(), IDGeneratorFunc(func(context.Context) (string, error) {
+		return "", genErr
+	}), "https://example.org", "marlo")
+	assert.ErrorIs(t, err, genErr)
+}
+
+func TestURL_DeletedBefore(t *testing.T) {
+	now := time.Now()
+	u := &URL{}
+	assert.False(t, u.DeletedBefore(now))
+
+	u.MarkDeleted(now.Add(-time.Hour))
+	assert.True(t, u.DeletedBefore(now))
+	assert.False(t, u.DeletedBefore(now.Add(-2*time.Hour)))
+
+	// deletion time is unknown for urls deleted before it was recorded
+	u.DeletedAt = nil
+	assert.True(t, u.DeletedBefore(now.Add(-2*time.Hour)))
+
+	u.Restore(now)
+	assert.False(t, u.IsDeleted)
+	assert.False(t, u.DeletedBefore(now))
+	assert.Equal(t, now, u.UpdatedAt)
+}
+
+func TestWithMetadata(t *testing.T) {
+	tt := []struct {
+		name  string
+		title string
+		note  string
+		tags  []string
+		want  []string
+		err   error
+	}{
+		{name: "empty"},
+		{name: "tags are normalized", title: " Title ", tags: []string{"News", " ads", "news"}, want: []string{"ads", "news"}},
+		{name: "long title", title: strings.Repeat("a", TitleMaxLength+1), err: ErrTitleTooLong},
+		{name: "long note", note: strings.Repeat("a", NoteMaxLength+1), err: ErrNoteTooLong},
+		{name: "empty tag", tags: []string{" "}, err: ErrTagBad},
+		{name: "bad tag charset", tags: []string{"with space"}, err: ErrTagBad},
+		{name: "long tag", tags: []string{strings.Repeat("a", TagMaxLength+1)}, err: ErrTagBad},
+		{name: "too many tags", tags: strings.Split("a b c d e f g h i j k l m n o p q r s t u", " "), err: ErrTooManyTags},
+	}
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			u := &URL{}
+			err := u.Apply(WithMetadata(tc.title, tc.note, tc.tags))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tc.title), u.Title)
+			assert.Equal(t, tc.note, u.Note)
+			assert.Equal(t, tc.want, u.Tags)
+			for _, tag := range tc.want {
+				assert.True(t, u.HasTag(tag))
+			}
+		})
+	}
+}
+
+func TestWithDestination(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, u.Apply(WithDestination("bad url", "")), ErrURLContainSpace)
+	assert.Equal(t, "https://example.org", u.BaseURL)
+
+	require.NoError(t, u.Apply(WithDestination("https://example.com/", "HTTPS://Example.com")))
+	assert.Equal(t, "https://example.com/", u.BaseURL)
+	assert.Equal(t, "HTTPS://Example.com", u.Display())
+
+	// same destination doesn't change url.
+	require.NoError(t, u.Apply(WithDestination("https://example.com/", "")))
+	assert.Equal(t, "HTTPS://Example.com", u.RawURL)
+
+	require.NoError(t, u.Apply(WithDestination("https://example.net/", "https://example.net/")))
+	assert.Equal(t, "https://example.net/", u.BaseURL)
+	assert.Empty(t, u.RawURL)
+}
+
+func TestWithRedirectCode(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRedirectCode, u.Redirect())
+
+	require.NoError(t, u.Apply(WithRedirectCode(0)))
+	assert.Equal(t, DefaultRedirectCode, u.Redirect())
+	assert.ErrorIs(t, u.Apply(WithRedirectCode(200)), ErrRedirectCodeBad)
+	for _, code := range RedirectCodes {
+		require.NoError(t, u.Apply(WithRedirectCode(uint32(code))))
+		assert.Equal(t, code, u.Redirect())
+	}
+
+	assert.Empty(t, OptionsOf(&UpdateURLRequest{URL: "https://example.com"}))
+	assert.Len(t, OptionsOf(&UpdateURLRequest{RedirectCode: 301}), 1)
+}
+
+func TestWithPassword(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(WithPassword("")))
+	assert.False(t, u.IsProtected())
+	assert.ErrorIs(t, u.Apply(WithPassword(strings.Repeat("a", PasswordMaxLength+1))), ErrPasswordTooLong)
+
+	require.NoError(t, u.Apply(WithPassword("secret")))
+	assert.True(t, u.IsProtected())
+	assert.NotContains(t, u.PasswordHash, "secret")
+	assert.True(t, u.CheckPassword("secret"))
+	assert.False(t, u.CheckPassword("Secret"))
+
+	require.NoError(t, u.Apply(OptionsOf(&UpdateURLRequest{RemovePassword: true})...))
+	assert.False(t, u.IsProtected())
+}
+
+func TestWithMaxClicks(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	assert.ErrorIs(t, u.Apply(WithMaxClicks(-1)), ErrMaxClicksNegative)
+	require.NoError(t, u.Apply(WithMaxClicks(0)))
+	assert.False(t, u.IsLimited())
+
+	require.NoError(t, u.Apply(WithMaxClicks(3)))
+	assert.True(t, u.IsLimited())
+	assert.Equal(t, int64(3), u.ClicksLeft)
+	u.ClicksLeft -= 2
+
+	// clicks which are already done are counted in new limit.
+	require.NoError(t, u.Apply(WithMaxClicks(5)))
+	assert.Equal(t, int64(3), u.ClicksLeft)
+	require.NoError(t, u.Apply(WithMaxClicks(1)))
+	assert.Equal(t, int64(0), u.ClicksLeft)
+	assert.True(t, u.IsExhausted())
+}
+
+func TestURL_IsStatic(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	assert.True(t, (&URL{BaseURL: "https://example.org"}).IsStatic())
+	for _, u := range []*URL{
+		{Rules: []*Rule{{Device: DeviceIOS}}},
+		{Variants: []*Variant{{Name: "a"}}},
+		{Passthrough: true},
+		{UTM: &UTM{Source: "news"}},
+		{PasswordHash: "hash"},
+		{ExpiresAt: &expires},
+		{MaxClicks: 1},
+	} {
+		assert.False(t, u.IsStatic())
+	}
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// const ...
+const (
+	// DefaultURLMaxLength is max length of original url in bytes which is used if policy has no max length.
+	DefaultURLMaxLength = 2048
+)
+
+// vars ...
+var (
+	// ErrURLTooLong ...
+	ErrURLTooLong = errors.New("url is too long")
+	// ErrURLBadFormat ...
+	ErrURLBadFormat = errors.New("url must be absolute url")
+	// ErrURLSchemeNotAllowed ...
+	ErrURLSchemeNotAllowed = errors.New("url scheme is not allowed")
+	// ErrURLNoHost ...
+	ErrURLNoHost = errors.New("url must have host")
+	// ErrURLPrivateHost ...
+	ErrURLPrivateHost = errors.New("url host must not be private or loopback address")
+
+	// DefaultURLSchemes are schemes of original urls which are allowed if policy has no schemes.
+	DefaultURLSchemes = []string{"http", "https"}
+
+	// urlErrors are errors of original url validation.
+	urlErrors = []error{
+		ErrURLContainSpace,
+		ErrURLTooShort,
+		ErrURLTooLong,
+		ErrURLBadFormat,
+		ErrURLSchemeNotAllowed,
+		ErrURLNoHost,
+		ErrURLPrivateHost,
+	}
+)
+
+// URLPolicy defines which original urls are valid. Empty schemes and zero max length of policy are replaced
+// with defaults, so zero policy allows http and https urls which are not longer than DefaultURLMaxLength.
+type URLPolicy struct {
+	// Schemes are allowed schemes of urls.
+	Schemes []string
+	// MaxLength is max length of url in bytes.
+	MaxLength int
+	// RejectPrivateHosts makes urls invalid if their host is localhost or loopback, private, link-local or
+	// unspecified ip address. Host names are not resolved.
+	RejectPrivateHosts bool
+}
+
+// CheckFormat returns error if raw isn't absolute url. It doesn't depend on policy, so it is used where
+// policy of service is unknown; urls are checked by policy with URL.Validate before they are stored.
+func CheckFormat(raw string) error {
+	_, err := parse(raw)
+	return err
+}
+
+// parse checks format of raw url and parses it.
+func parse(raw string) (*url.URL, error) {
+	switch {
+	case strings.Contains(raw, " "):
+		return nil, ErrURLContainSpace
+	case len(raw) < 4:
+		return nil, ErrURLTooShort
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return nil, ErrURLBadFormat
+	}
+	return u, nil
+}
+
+// Check returns error if raw url isn't valid by policy.
+func (p URLPolicy) Check(raw string) error {
+	u, err := parse(raw)
+	if err != nil {
+		return err
+	}
+	maxLength := p.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultURLMaxLength
+	}
+	if len(raw) > maxLength {
+		return ErrURLTooLong
+	}
+	if !p.allowedScheme(u.Scheme) {
+		return ErrURLSchemeNotAllowed
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return ErrURLNoHost
+	}
+	if p.RejectPrivateHosts && isPrivateHost(host) {
+		return ErrURLPrivateHost
+	}
+	return nil
+}
+
+// allowedScheme ...
+func (p URLPolicy) allowedScheme(scheme string) bool {
+	schemes := p.Schemes
+	if len(schemes) == 0 {
+		schemes = DefaultURLSchemes
+	}
+	for _, allowed := range schemes {
+		if strings.EqualFold(scheme, strings.TrimSpace(allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrivateHost reports whether host is localhost or ip address which isn't reachable from internet.
+func isPrivateHost(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified()
+}
+
+// URLError returns error of original url validation which err is caused by or nil if err isn't caused by
+// invalid original url.
+func URLError(err error) error {
+	for _, target := range urlErrors {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// const ...
+const (
+	// MaxVariants is max count of split variants of one url.
+	MaxVariants = 10
+	// VariantNameMaxLength ...
+	VariantNameMaxLength = 32
+	// VariantMaxWeight ...
+	VariantMaxWeight = 10000
+)
+
+// vars ...
+var (
+	// ErrVariantBad ...
+	ErrVariantBad = errors.New("bad split variant")
+	// ErrTooManyVariants ...
+	ErrTooManyVariants = errors.New("url may have at most 10 split variants")
+)
+
+// Variant is split variant of url. Visitors of url with variants are distributed across destinations of
+// variants proportionally to their weights instead of original url.
+type Variant struct {
+	// Name identifies variant in stats and in cookie of visitor, so repeat visits land on same variant.
+	Name        string `json:"name"`
+	Destination string `json:"destination"`
+	Weight      int    `json:"weight"`
+}
+
+// WithVariants sets split variants of url. Variants without name are named by their position starting
+// from 1. Empty variants are ignored.
+func WithVariants(variants []*Variant) URLOption {
+	return func(u *URL) error {
+		if len(variants) == 0 {
+			return nil
+		}
+		if len(variants) > MaxVariants {
+			return ErrTooManyVariants
+		}
+		res := make([]*Variant, 0, len(variants))
+		names := make(map[string]struct{}, len(variants))
+		for i, v := range variants {
+			if v == nil {
+				return fmt.Errorf("%w %d: variant is empty", ErrVariantBad, i)
+			}
+			normalized := *v
+			if normalized.Name == "" {
+				normalized.Name = fmt.Sprint(i + 1)
+			}
+			if err := normalized.validate(); err != nil {
+				return fmt.Errorf("%w %d: %v", ErrVariantBad, i, err)
+			}
+			if _, ok := names[normalized.Name]; ok {
+				return fmt.Errorf("%w %d: name %q is not unique", ErrVariantBad, i, normalized.Name)
+			}
+			names[normalized.Name] = struct{}{}
+			res = append(res, &normalized)
+		}
+		u.Variants = res
+		return nil
+	}
+}
+
+// WithoutVariants removes all split variants of url.
+func WithoutVariants() URLOption {
+	return func(u *URL) error {
+		u.Variants = nil
+		return nil
+	}
+}
+
+// validate ...
+func (v *Variant) validate() error {
+	if len(v.Name) > VariantNameMaxLength {
+		return fmt.Errorf("name must be at most %d chars long", VariantNameMaxLength)
+	}
+	for _, r := range v.Name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return errors.New("name may contain only latin letters, digits, '-' and '_'")
+		}
+	}
+	if v.Weight < 1 || v.Weight > VariantMaxWeight {
+		return fmt.Errorf("weight must be from 1 to %d", VariantMaxWeight)
+	}
+	if err := CheckFormat(v.Destination); err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+	return nil
+}
+
+// variant returns variant of url with provided name or nil if there is no such variant.
+func (u *URL) variant(name string) *Variant {
+	for _, v := range u.Variants {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// pickVariant returns variant which is chosen for roll from 0 to sum of weights of variants.
+func (u *URL) pickVariant(roll int) *Variant {
+	for _, v := range u.Variants {
+		if roll < v.Weight {
+			return v
+		}
+		roll -= v.Weight
+	}
+	return u.Variants[len(u.Variants)-1]
+}
+
+// chooseVariant returns variant of visitor: variant which was chosen for visitor before if url still has
+// it, or randomly chosen variant by weights otherwise.
+func (u *URL) chooseVariant(v *Visitor) *Variant {
+	if variant := u.variant(v.Variant); variant != nil {
+		return variant
+	}
+	var total int
+	for _, variant := range u.Variants {
+		total += variant.Weight
+	}
+	return u.pickVariant(rand.Intn(total))
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithVariants(t *testing.T) {
+	tt := []struct {
+		name     string
+		variants []*Variant
+		err      error
+	}{
+		{name: "named", variants: []*Variant{
+			{Name: "a", Destination: "https://example.com/a", Weight: 70},
+			{Name: "b", Destination: "https://example.com/b", Weight: 30},
+		}},
+		{name: "nil variant", variants: []*Variant{nil}, err: ErrVariantBad},
+		{name: "zero weight", variants: []*Variant{{Destination: "https://example.com"}}, err: ErrVariantBad},
+		{name: "big weight", variants: []*Variant{{Destination: "https://example.com", Weight: VariantMaxWeight + 1}}, err: ErrVariantBad},
+		{name: "bad destination", variants: []*Variant{{Destination: "bad url", Weight: 1}}, err: ErrVariantBad},
+		{name: "bad name", variants: []*Variant{{Name: "a b", Destination: "https://example.com", Weight: 1}}, err: ErrVariantBad},
+		{name: "duplicate name", variants: []*Variant{
+			{Name: "2", Destination: "https://example.com/a", Weight: 1},
+			{Destination: "https://example.com/b", Weight: 1},
+		}, err: ErrVariantBad},
+		{name: "too many variants", variants: make([]*Variant, MaxVariants+1), err: ErrTooManyVariants},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := NewURL("https://example.org", "marlo")
+			require.NoError(t, err)
+			err = u.Apply(WithVariants(tc.variants))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, u.Variants)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.variants, u.Variants)
+		})
+	}
+
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	variants := []*Variant{{Destination: "https://example.com/a", Weight: 1}, {Destination: "https://example.com/b", Weight: 1}}
+	require.NoError(t, u.Apply(WithVariants(variants)))
+	assert.Equal(t, "1", u.Variants[0].Name)
+	assert.Equal(t, "2", u.Variants[1].Name)
+	assert.Empty(t, variants[0].Name, "variants of request must not be changed")
+	require.NoError(t, u.Apply(OptionsOf(&UpdateURLRequest{ClearVariants: true})...))
+	assert.Nil(t, u.Variants)
+}
+
+func TestURL_Target_Variants(t *testing.T) {
+	u, err := NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, u.Apply(
+		WithRules([]*Rule{{Device: DeviceIOS, Destination: "https://apps.apple.com"}}),
+		WithVariants([]*Variant{
+			{Name: "a", Destination: "https://example.org/a", Weight: 70},
+			{Name: "b", Destination: "https://example.org/b", Weight: 30},
+		}),
+	))
+
+	t.Run("rule", func(t *testing.T) {
+		target, variant := u.Target(&Visitor{UserAgent: iPhoneUA, Variant: "a"})
+		assert.Equal(t, "https://apps.apple.com", target)
+		assert.Nil(t, variant)
+	})
+	t.Run("sticky", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			target, variant := u.Target(&Visitor{UserAgent: desktopUA, Variant: "b"})
+			assert.Equal(t, "https://example.org/b", target)
+			require.NotNil(t, variant)
+			assert.Equal(t, "b", variant.Name)
+		}
+	})
+	t.Run("weights", func(t *testing.T) {
+		assert.Equal(t, "a", u.pickVariant(0).Name)
+		assert.Equal(t, "a", u.pickVariant(69).Name)
+		assert.Equal(t, "b", u.pickVariant(70).Name)
+		assert.Equal(t, "b", u.pickVariant(99).Name)
+
+		counts := make(map[string]int)
+		for i := 0; i < 1000; i++ {
+			target, variant := u.Target(&Visitor{UserAgent: desktopUA, Variant: "removed"})
+			require.NotNil(t, variant)
+			assert.Equal(t, variant.Destination, target)
+			counts[variant.Name]++
+		}
+		assert.InDelta(t, 700, counts["a"], 100)
+		assert.InDelta(t, 300, counts["b"], 100)
+	})
+}
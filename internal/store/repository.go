@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/vlad-marlo/shortener/internal/store/model"
 )
@@ -14,6 +15,9 @@ const (
 	FileBasedStorage string = "file-based"
 	// SQLStore ...
 	SQLStore string = "sql-store"
+
+	// MaxIDAttempts is count of attempts to generate id of url which is not taken yet.
+	MaxIDAttempts = 10
 )
 
 // Store ...
@@ -26,12 +30,38 @@ type Store interface {
 	Create(context.Context, *model.URL) error
 	// GetByID ...
 	GetByID(context.Context, string) (*model.URL, error)
-	// GetAllUserURLs ...
-	GetAllUserURLs(context.Context, string) ([]*model.URL, error)
+	// ListUserURLs returns page of not deleted urls which are created by user and match options.
+	ListUserURLs(ctx context.Context, user string, opts ListOptions) (*Page, error)
 	// URLsBulkCreate ...
 	URLsBulkCreate(context.Context, []*model.URL) ([]*model.BatchCreateURLsResponse, error)
 	// URLsBulkDelete ...
 	URLsBulkDelete([]string, string) error
+	// URLsBulkRestore restores deleted urls with provided ids which are created by user and returns count
+	// of restored urls.
+	URLsBulkRestore(ctx context.Context, ids []string, user string) (int64, error)
+	// PurgeDeleted physically removes urls which were deleted before provided time with their clicks and
+	// returns count of removed urls.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 	// GetData ...
 	GetData(ctx context.Context) (*model.InternalStat, error)
+	// SweepExpired marks all expired urls which are not swept yet as swept and returns count of swept urls.
+	// Swept urls are not deleted: they are still reported by ErrExpired.
+	SweepExpired(ctx context.Context) (int64, error)
+	// SaveClicks ...
+	SaveClicks(ctx context.Context, clicks []*model.Click) error
+	// GetLinkStats returns aggregated clicks of url with provided id or ErrNotFound if url doesn't exist.
+	GetLinkStats(ctx context.Context, id string) (*model.LinkStats, error)
+	// UpdateURL applies options to not deleted url with provided id and saves changed url atomically:
+	// original url, which is changed by model.WithDestination, and settings which may be changed after
+	// creation: redirect code, password, limit of clicks, targeting rules, split variants, query passthrough
+	// and utm template. Previous original url is kept in history. ErrAlreadyExists is returned if new
+	// original url is duplicate of other url.
+	UpdateURL(ctx context.Context, id string, opts ...model.URLOption) (*model.URL, error)
+	// TakeClick decrements remaining count of clicks of url with limited clicks atomically and returns
+	// url. ErrExhausted is returned if all clicks of url are done. Url with unlimited clicks is just returned.
+	TakeClick(ctx context.Context, id string) (*model.URL, error)
+	// GetHistory returns previous original urls of url with provided id from oldest to newest.
+	GetHistory(ctx context.Context, id string) ([]*model.Destination, error)
+	// NextSequence returns next value of counter which is used by counter based id generators.
+	NextSequence(ctx context.Context) (uint64, error)
 }
@@ -0,0 +1,294 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// migrationsLockID is key of postgres advisory lock which is held while migrations are running,
+// so two instances of service never migrate db concurrently.
+const migrationsLockID int64 = 0x73686f7274656e
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationFileRe matches names of migration files like 0001_create_urls.up.sql.
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// vars ...
+var (
+	// ErrNoMigrationsToRollback ...
+	ErrNoMigrationsToRollback = errors.New("there are no applied migrations")
+	// ErrUnknownMigration ...
+	ErrUnknownMigration = errors.New("db has applied migration which is unknown to service")
+)
+
+// types ...
+type (
+	// Migration is one versioned change of db schema.
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// MigrationStatus ...
+	MigrationStatus struct {
+		Version   int64
+		Name      string
+		Applied   bool
+		AppliedAt *time.Time
+	}
+
+	// Migrator applies and rolls back embedded migrations.
+	Migrator struct {
+		db         *sql.DB
+		l          *zap.Logger
+		migrations []*Migration
+	}
+)
+
+// NewMigrator ...
+func NewMigrator(db *sql.DB, l *zap.Logger) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationsFS)
+	if err != nil {
+		return nil, fmt.Errorf("load migrations: %w", err)
+	}
+	return &Migrator{
+		db:         db,
+		l:          l,
+		migrations: migrations,
+	}, nil
+}
+
+// loadMigrations reads migrations from fsys and returns them sorted by version.
+func loadMigrations(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, f := range files {
+		match := migrationFileRe.FindStringSubmatch(f[len("migrations/"):])
+		if match == nil {
+			return nil, fmt.Errorf("bad migration file name: %s", f)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse version of %s: %w", f, err)
+		}
+		data, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// withLock runs fn on single connection which holds migrations advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get conn: %w", err)
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			m.l.Warn(fmt.Sprintf("migrate: close conn: %v", closeErr))
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, migrationsLockID); err != nil {
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	defer func() {
+		if _, unlockErr := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, migrationsLockID); unlockErr != nil {
+			m.l.Error(fmt.Sprintf("migrate: release lock: %v", unlockErr))
+		}
+	}()
+
+	if _, err = conn.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations(
+			version BIGINT PRIMARY KEY NOT NULL,
+			name VARCHAR NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);`,
+	); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// applied returns applied migrations with time of applying.
+func (m *Migrator) applied(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}) (map[int64]time.Time, error) {
+	r, err := q.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, fmt.Errorf("query db: %w", err)
+	}
+	defer func(r *sql.Rows) {
+		if err := r.Close(); err != nil {
+			m.l.Warn(fmt.Sprintf("closing rows: %v", err))
+		}
+	}(r)
+
+	res := make(map[int64]time.Time)
+	for r.Next() {
+		var (
+			version int64
+			at      time.Time
+		)
+		if err = r.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		res[version] = at
+	}
+	if err = r.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return res, nil
+}
+
+// run executes migration query and updates schema_migrations in one transaction.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, query, versionQuery string, mig *Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			m.l.Error(fmt.Sprintf("migrate: unable to rollback: %v", err))
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if _, err = tx.ExecContext(ctx, versionQuery, mig.Version, mig.Name); err != nil {
+		return fmt.Errorf("update schema_migrations: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Up applies all migrations which are not applied yet.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err = m.run(
+				ctx,
+				conn,
+				mig.Up,
+				`INSERT INTO schema_migrations(version, name) VALUES ($1, $2);`,
+				mig,
+			); err != nil {
+				return err
+			}
+			m.l.Info("applied migration", zap.Int64("version", mig.Version), zap.String("name", mig.Name))
+		}
+		return nil
+	})
+}
+
+// Down rolls back last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		var last int64 = -1
+		for version := range applied {
+			if version > last {
+				last = version
+			}
+		}
+		if last < 0 {
+			return ErrNoMigrationsToRollback
+		}
+		for _, mig := range m.migrations {
+			if mig.Version != last {
+				continue
+			}
+			if err = m.run(
+				ctx,
+				conn,
+				mig.Down,
+				`DELETE FROM schema_migrations WHERE version = $1 AND name = $2;`,
+				mig,
+			); err != nil {
+				return err
+			}
+			m.l.Info("rolled back migration", zap.Int64("version", mig.Version), zap.String("name", mig.Name))
+			return nil
+		}
+		return fmt.Errorf("version %d: %w", last, ErrUnknownMigration)
+	})
+}
+
+// Status returns all known migrations with info about applying.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	var res []*MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			st := &MigrationStatus{
+				Version: mig.Version,
+				Name:    mig.Name,
+			}
+			if at, ok := applied[mig.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = &at
+			}
+			res = append(res, st)
+		}
+		return nil
+	})
+	return res, err
+}
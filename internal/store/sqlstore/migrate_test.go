@@ -0,0 +1,77 @@
+package sqlstore
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationsFS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.Equal(t, int64(i+1), m.Version, "migrations must be numbered without gaps")
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	tt := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"migrations/0010_b.up.sql":   {Data: []byte("up")},
+				"migrations/0010_b.down.sql": {Data: []byte("down")},
+				"migrations/0002_a.up.sql":   {Data: []byte("up")},
+				"migrations/0002_a.down.sql": {Data: []byte("down")},
+			},
+			want: []int64{2, 10},
+		},
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{
+				"migrations/0001_a.up.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad name",
+			fsys: fstest.MapFS{
+				"migrations/first.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "different names of one version",
+			fsys: fstest.MapFS{
+				"migrations/0001_a.up.sql":   {Data: []byte("up")},
+				"migrations/0001_b.down.sql": {Data: []byte("down")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tc.fsys)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var got []int64
+			for _, m := range migrations {
+				got = append(got, m.Version)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS urls(
+    id SERIAL UNIQUE PRIMARY KEY NOT NULL,
+    short VARCHAR,
+    original_url VARCHAR UNIQUE,
+    created_by VARCHAR,
+    is_deleted BOOL DEFAULT FALSE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS is_swept BOOL NOT NULL DEFAULT FALSE;
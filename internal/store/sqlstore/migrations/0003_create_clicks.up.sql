@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS clicks(
+    id SERIAL PRIMARY KEY NOT NULL,
+    short VARCHAR NOT NULL,
+    clicked_at TIMESTAMPTZ NOT NULL,
+    referer VARCHAR,
+    user_agent VARCHAR,
+    ip VARCHAR
+);
+CREATE INDEX IF NOT EXISTS clicks_short_idx ON clicks(short);
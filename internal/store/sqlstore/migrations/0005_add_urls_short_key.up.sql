@@ -0,0 +1,4 @@
+-- short ids were not unique before, so every duplicate except the oldest one gets id which is made unique by row id.
+UPDATE urls SET short = short || '-' || id
+WHERE short IS NOT NULL AND id NOT IN (SELECT MIN(id) FROM urls WHERE short IS NOT NULL GROUP BY short);
+CREATE UNIQUE INDEX IF NOT EXISTS urls_short_key ON urls(short);
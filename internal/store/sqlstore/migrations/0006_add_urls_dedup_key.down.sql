@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS urls_created_by_idx;
+ALTER TABLE urls ADD CONSTRAINT urls_original_url_key UNIQUE (original_url);
+DROP INDEX IF EXISTS urls_dedup_key;
+ALTER TABLE urls DROP COLUMN IF EXISTS dedup_key;
@@ -0,0 +1,5 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS dedup_key VARCHAR;
+UPDATE urls SET dedup_key = original_url WHERE dedup_key IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS urls_dedup_key ON urls(dedup_key);
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_original_url_key;
+CREATE INDEX IF NOT EXISTS urls_created_by_idx ON urls(created_by);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS urls_created_by_original_url_idx;
+DROP INDEX IF EXISTS urls_created_by_created_at_idx;
+ALTER TABLE urls DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+CREATE INDEX IF NOT EXISTS urls_created_by_created_at_idx ON urls(created_by, created_at, short);
+CREATE INDEX IF NOT EXISTS urls_created_by_original_url_idx ON urls(created_by, original_url, short);
@@ -0,0 +1,3 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+UPDATE urls SET updated_at = COALESCE(deleted_at, created_at) WHERE updated_at IS NULL;
+ALTER TABLE urls ALTER COLUMN updated_at SET DEFAULT NOW(), ALTER COLUMN updated_at SET NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS url_tags;
+ALTER TABLE urls DROP COLUMN IF EXISTS note;
+ALTER TABLE urls DROP COLUMN IF EXISTS title;
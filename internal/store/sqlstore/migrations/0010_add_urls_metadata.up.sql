@@ -0,0 +1,8 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS title VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS note VARCHAR NOT NULL DEFAULT '';
+CREATE TABLE IF NOT EXISTS url_tags(
+    short VARCHAR NOT NULL,
+    tag VARCHAR NOT NULL,
+    PRIMARY KEY (short, tag)
+);
+CREATE INDEX IF NOT EXISTS url_tags_tag_idx ON url_tags(tag, short);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS url_history(
+    id SERIAL PRIMARY KEY NOT NULL,
+    short VARCHAR NOT NULL,
+    original_url VARCHAR NOT NULL,
+    replaced_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS url_history_short_idx ON url_history(short, replaced_at);
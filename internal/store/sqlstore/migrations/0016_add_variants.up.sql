@@ -0,0 +1,2 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS variant VARCHAR NOT NULL DEFAULT '';
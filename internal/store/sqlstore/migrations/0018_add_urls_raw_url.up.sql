@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS raw_url VARCHAR NOT NULL DEFAULT '';
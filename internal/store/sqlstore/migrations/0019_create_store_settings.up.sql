@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS store_settings(
+    name VARCHAR PRIMARY KEY,
+    value VARCHAR NOT NULL
+);
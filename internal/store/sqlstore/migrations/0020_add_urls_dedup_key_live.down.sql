@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS urls_dedup_key;
+UPDATE urls SET dedup_key = NULL
+WHERE is_deleted = true AND EXISTS (
+    SELECT 1 FROM urls o
+    WHERE o.dedup_key = urls.dedup_key AND o.short <> urls.short AND (o.is_deleted = false OR o.short < urls.short)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS urls_dedup_key ON urls(dedup_key);
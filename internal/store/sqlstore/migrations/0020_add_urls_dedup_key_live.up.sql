@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS urls_dedup_key;
+CREATE UNIQUE INDEX IF NOT EXISTS urls_dedup_key ON urls(dedup_key) WHERE is_deleted = false;
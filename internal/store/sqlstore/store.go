@@ -3,7 +3,10 @@ package sqlstore
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/lib/pq"
@@ -15,8 +18,9 @@ import (
 
 // SQLStore ...
 type SQLStore struct {
-	DB *sql.DB
-	l  *zap.Logger
+	DB    *sql.DB
+	l     *zap.Logger
+	dedup store.DedupScope
 }
 
 // New create new connection to db with provided connection string. If db is not nil than will be used it as db.
@@ -44,115 +48,398 @@ func New(ctx context.Context, connectString string, l *zap.Logger, db *sql.DB) (
 	return s, nil
 }
 
-// migrate run migrations instead of go-migrate package
+// migrate applies all embedded migrations which are not applied yet.
 func (s *SQLStore) migrate(ctx context.Context) error {
-	_, err := s.DB.ExecContext(
-		ctx,
-		`CREATE TABLE IF NOT EXISTS urls(
-			id SERIAL UNIQUE PRIMARY KEY NOT NULL,
-			short VARCHAR,
-			original_url VARCHAR UNIQUE,
-			created_by VARCHAR,
-			is_deleted BOOL DEFAULT FALSE
-		);`,
-	)
-	return err
+	m, err := NewMigrator(s.DB, s.l)
+	if err != nil {
+		return err
+	}
+	return m.Up(ctx)
 }
 
-// Create ...
-func (s *SQLStore) Create(ctx context.Context, u *model.URL) error {
-	_, err := s.DB.ExecContext(
-		ctx,
-		`INSERT INTO urls(short, original_url, created_by) VALUES ($1, $2, $3);`,
-		u.ID,
-		u.BaseURL,
-		u.User,
-	)
+// const ...
+const (
+	// urlsShortKey is name of unique index of short ids.
+	urlsShortKey = "urls_short_key"
+	// urlsDedupKey is name of unique index of dedup keys.
+	urlsDedupKey = "urls_dedup_key"
+)
+
+// SetDedupScope sets scope of urls deduplication. It must be called before store is used; SyncDedupKeys
+// must be called after it if scope may differ from scope of stored urls.
+func (s *SQLStore) SetDedupScope(scope store.DedupScope) {
+	s.dedup = scope
+}
 
+// dedupScopeSetting is name of setting which keeps scope in which dedup keys of urls were computed.
+const dedupScopeSetting = "dedup_scope"
+
+// SyncDedupKeys recomputes dedup keys of all urls if they were computed in other scope than scope of store,
+// so stored keys always match keys which are computed on creation. Only the oldest live url keeps key if
+// several live urls get same key. Deleted urls always keep their keys: keys are unique among live urls only.
+func (s *SQLStore) SyncDedupKeys(ctx context.Context) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		pgErr, ok := err.(*pq.Error)
-		if ok && pgErr.Code == pgerrcode.UniqueViolation {
-			if err = s.GetByOriginalURL(ctx, u); err != nil {
-				return err
-			}
-			return store.ErrAlreadyExists
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			s.l.Error(fmt.Sprintf("sync dedup keys: unable to rollback: %v", err))
 		}
-		return err
+	}()
+
+	// settings are locked, so instances which are started at once don't recompute keys concurrently.
+	if _, err = tx.ExecContext(ctx, `LOCK TABLE store_settings IN EXCLUSIVE MODE;`); err != nil {
+		return fmt.Errorf("lock settings: %w", err)
+	}
+	var scope string
+	err = tx.QueryRowContext(ctx, `SELECT value FROM store_settings WHERE name = $1;`, dedupScopeSetting).Scan(&scope)
+	switch {
+	case err == nil && store.DedupScope(scope) == s.dedup:
+		return nil
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("select dedup scope: %w", err)
 	}
-	return nil
-}
 
-// GetByOriginalURL return url with original url which is provided in u.BaseURL.
-func (s *SQLStore) GetByOriginalURL(ctx context.Context, u *model.URL) error {
-	if err := s.DB.QueryRowContext(
+	rows, err := tx.QueryContext(ctx, `SELECT `+urlColumns+` FROM urls ORDER BY is_deleted, created_at, short;`)
+	if err != nil {
+		return fmt.Errorf("select urls: %w", err)
+	}
+	keys := make(map[string]string)
+	taken := make(map[string]bool)
+	for rows.Next() {
+		u, err := scanURL(rows)
+		if err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan url: %w", err)
+		}
+		if key := s.dedup.Key(u); key != "" && (u.IsDeleted || !taken[key]) {
+			taken[key] = true
+			keys[u.ID] = key
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return fmt.Errorf("close rows: %w", err)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE urls SET dedup_key = NULL WHERE dedup_key IS NOT NULL;`); err != nil {
+		return fmt.Errorf("reset dedup keys: %w", err)
+	}
+	for id, key := range keys {
+		if _, err = tx.ExecContext(ctx, `UPDATE urls SET dedup_key = $2 WHERE short = $1;`, id, key); err != nil {
+			return fmt.Errorf("update dedup key: %w", err)
+		}
+	}
+	if _, err = tx.ExecContext(
 		ctx,
-		`SELECT short, is_deleted FROM urls WHERE original_url = $1;`,
-		u.BaseURL,
-	).Scan(&u.ID, &u.IsDeleted); err != nil {
-		return err
+		`INSERT INTO store_settings(name, value) VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET value = EXCLUDED.value;`,
+		dedupScopeSetting,
+		string(s.dedup),
+	); err != nil {
+		return fmt.Errorf("save dedup scope: %w", err)
 	}
-	if u.IsDeleted {
-		return store.ErrIsDeleted
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
 	}
 	return nil
 }
 
-// GetByID return url with provided url
-func (s *SQLStore) GetByID(ctx context.Context, id string) (*model.URL, error) {
-	u := &model.URL{}
+// dedupKey returns dedup key of u in scope of store. Urls which have no duplicates get NULL key, which
+// never violates unique index.
+func (s *SQLStore) dedupKey(u *model.URL) sql.NullString {
+	key := s.dedup.Key(u)
+	return sql.NullString{String: key, Valid: key != ""}
+}
 
-	if err := s.DB.QueryRowContext(
-		ctx,
-		`SELECT short, original_url, created_by, is_deleted FROM urls WHERE short=$1;`,
-		id,
-	).Scan(
+// urlColumns are columns of urls table which are scanned by scanURL.
+const urlColumns = `short, original_url, created_by, is_deleted, expires_at, created_at, updated_at, deleted_at, title, note,
+	redirect_code, password_hash, max_clicks, clicks_left, rules, variants, passthrough, utm, raw_url, is_swept,
+	ARRAY(SELECT tag FROM url_tags WHERE url_tags.short = urls.short ORDER BY tag)`
+
+// insertURLQuery inserts url with its tags in one statement.
+const insertURLQuery = `WITH u AS (
+	INSERT INTO urls(short, original_url, created_by, expires_at, dedup_key, created_at, updated_at, title, note,
+		redirect_code, password_hash, max_clicks, clicks_left, rules, variants, passthrough, utm, raw_url)
+	VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()), COALESCE($6, NOW()), $7, $8, $10, $11, $12, $13, $14, $15,
+		$16, $17, $18)
+	RETURNING short
+)
+INSERT INTO url_tags(short, tag) SELECT u.short, tag FROM u, unnest($9::VARCHAR[]) AS tag;`
+
+// scanURL scans url from row which contains urlColumns. Times are converted to UTC.
+func scanURL(row interface {
+	Scan(dest ...interface{}) error
+}) (*model.URL, error) {
+	u := new(model.URL)
+	var rules, variants, utm []byte
+	if err := row.Scan(
 		&u.ID,
 		&u.BaseURL,
 		&u.User,
 		&u.IsDeleted,
+		&u.ExpiresAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+		&u.DeletedAt,
+		&u.Title,
+		&u.Note,
+		&u.RedirectCode,
+		&u.PasswordHash,
+		&u.MaxClicks,
+		&u.ClicksLeft,
+		&rules,
+		&variants,
+		&u.Passthrough,
+		&utm,
+		&u.RawURL,
+		&u.IsSwept,
+		pq.Array(&u.Tags),
 	); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(rules, &u.Rules); err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+	if len(u.Rules) == 0 {
+		u.Rules = nil
+	}
+	if err := json.Unmarshal(variants, &u.Variants); err != nil {
+		return nil, fmt.Errorf("decode variants: %w", err)
+	}
+	if len(u.Variants) == 0 {
+		u.Variants = nil
+	}
+	if len(utm) != 0 {
+		if err := json.Unmarshal(utm, &u.UTM); err != nil {
+			return nil, fmt.Errorf("decode utm: %w", err)
+		}
+	}
+	if len(u.Tags) == 0 {
+		u.Tags = nil
+	}
+	u.CreatedAt = u.CreatedAt.UTC()
+	u.UpdatedAt = u.UpdatedAt.UTC()
+	if u.DeletedAt != nil {
+		deletedAt := u.DeletedAt.UTC()
+		u.DeletedAt = &deletedAt
+	}
+	return u, nil
+}
+
+// insertArgs returns arguments of insertURLQuery for u.
+func (s *SQLStore) insertArgs(u *model.URL) ([]interface{}, error) {
+	rules, err := jsonArray(u.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("encode rules: %w", err)
+	}
+	variants, err := jsonArray(u.Variants)
+	if err != nil {
+		return nil, fmt.Errorf("encode variants: %w", err)
+	}
+	utm, err := utmJSON(u)
+	if err != nil {
+		return nil, fmt.Errorf("encode utm: %w", err)
+	}
+	return []interface{}{
+		u.ID,
+		u.BaseURL,
+		u.User,
+		u.ExpiresAt,
+		s.dedupKey(u),
+		createdAt(u),
+		u.Title,
+		u.Note,
+		pq.Array(u.Tags),
+		u.Redirect(),
+		u.PasswordHash,
+		u.MaxClicks,
+		u.ClicksLeft,
+		rules,
+		variants,
+		u.Passthrough,
+		utm,
+		u.RawURL,
+	}, nil
+}
+
+// jsonArray returns slice v which is stored in jsonb column, nil slice is stored as empty array. Value is
+// passed as string: bytes are encoded by driver as bytea.
+func jsonArray(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return "[]", err
+	}
+	return string(data), nil
+}
+
+// utmJSON returns utm template of u which is stored in nullable jsonb column.
+func utmJSON(u *model.URL) (sql.NullString, error) {
+	if u.UTM == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(u.UTM)
+	return sql.NullString{String: string(data), Valid: err == nil}, err
+}
+
+// createdAt returns creation time of u. Urls without creation time get time of insertion.
+func createdAt(u *model.URL) sql.NullTime {
+	return sql.NullTime{Time: u.CreatedAt, Valid: !u.CreatedAt.IsZero()}
+}
+
+// uniqueViolation returns name of unique constraint or index which is violated by err. If err is not
+// unique violation, empty string is returned.
+func uniqueViolation(err error) string {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return pgErr.Constraint
+	}
+	return ""
+}
+
+// insertURL calls insert and regenerates id of u while insert violates uniqueness of short ids.
+func insertURL(ctx context.Context, u *model.URL, insert func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := insert()
+		if uniqueViolation(err) != urlsShortKey {
+			return err
+		}
+		if u.HasAlias() {
+			return store.ErrAliasTaken
+		}
+		if attempt == store.MaxIDAttempts {
+			return store.ErrIDCollision
+		}
+		if err = u.ShortURL(ctx); err != nil {
+			return fmt.Errorf("short url: %w", err)
+		}
+	}
+}
+
+// Create ...
+func (s *SQLStore) Create(ctx context.Context, u *model.URL) error {
+	err := insertURL(ctx, u, func() error {
+		args, err := s.insertArgs(u)
+		if err != nil {
+			return err
+		}
+		_, err = s.DB.ExecContext(ctx, insertURLQuery, args...)
+		return err
+	})
+	if uniqueViolation(err) == urlsDedupKey {
+		if err = s.GetDuplicate(ctx, u); err != nil {
+			return err
+		}
+		return store.ErrAlreadyExists
+	}
+	return err
+}
+
+// GetDuplicate sets id of url which is duplicate of u to u.ID. Deleted urls keep their dedup keys, but they
+// are not duplicates: dedup keys are unique among live urls only.
+func (s *SQLStore) GetDuplicate(ctx context.Context, u *model.URL) error {
+	return s.DB.QueryRowContext(
+		ctx,
+		`SELECT short FROM urls WHERE dedup_key = $1 AND is_deleted = false;`,
+		s.dedupKey(u),
+	).Scan(&u.ID)
+}
+
+// GetByID return url with provided url
+func (s *SQLStore) GetByID(ctx context.Context, id string) (*model.URL, error) {
+	u, err := scanURL(s.DB.QueryRowContext(
+		ctx,
+		`SELECT `+urlColumns+` FROM urls WHERE short=$1;`,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	if u.IsDeleted {
 		return nil, store.ErrIsDeleted
 	}
+	if u.IsExpired() {
+		return nil, store.ErrExpired
+	}
 	return u, nil
 }
 
-// GetAllUserURLs return all urls which are created by provided user.
-func (s *SQLStore) GetAllUserURLs(ctx context.Context, userID string) ([]*model.URL, error) {
-	var urls []*model.URL
+// listSortColumns are columns by which urls are sorted.
+var listSortColumns = map[store.ListSort]string{
+	store.SortCreatedAsc:  "created_at",
+	store.SortCreatedDesc: "created_at",
+	store.SortURLAsc:      "original_url",
+	store.SortURLDesc:     "original_url",
+}
 
-	r, err := s.DB.QueryContext(
-		ctx,
-		`SELECT short, original_url, created_by FROM urls WHERE created_by=$1 AND is_deleted = false;`,
-		userID,
-	)
+// ListUserURLs returns page of urls of user. Urls are sorted by index on user and sort column with short id
+// as tie-breaker, so next page is requested by keyset condition instead of offset.
+func (s *SQLStore) ListUserURLs(ctx context.Context, user string, opts store.ListOptions) (*store.Page, error) {
+	opts, cursor, err := opts.Normalize()
 	if err != nil {
-		return nil, fmt.Errorf("query db: %w", err)
-	}
-	if err := r.Err(); err != nil {
 		return nil, err
 	}
+	column := listSortColumns[opts.Sort]
+	order, cmp := "ASC", ">"
+	if opts.Sort.Descending() {
+		order, cmp = "DESC", "<"
+	}
+
+	args := []interface{}{user}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	query := `SELECT ` + urlColumns + ` FROM urls WHERE created_by=$1 AND is_deleted = false`
+	if opts.Query != "" {
+		query += fmt.Sprintf(" AND strpos(lower(original_url), lower(%s)) > 0", arg(opts.Query))
+	}
+	if !opts.CreatedFrom.IsZero() {
+		query += fmt.Sprintf(" AND created_at >= %s", arg(opts.CreatedFrom))
+	}
+	if !opts.CreatedTo.IsZero() {
+		query += fmt.Sprintf(" AND created_at < %s", arg(opts.CreatedTo))
+	}
+	if opts.Tag != "" {
+		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM url_tags WHERE url_tags.short = urls.short AND tag = %s)", arg(opts.Tag))
+	}
+	if cursor != nil {
+		var key interface{} = cursor.CreatedAt
+		if column == "original_url" {
+			key = cursor.URL
+		}
+		query += fmt.Sprintf(" AND (%s, short) %s (%s, %s)", column, cmp, arg(key), arg(cursor.ID))
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, short %[2]s LIMIT %[3]s;", column, order, arg(opts.Limit+1))
+
+	r, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query db: %w", err)
+	}
 	defer func(r *sql.Rows) {
 		if err := r.Close(); err != nil {
 			s.l.Warn(fmt.Sprintf("closing rows: %v", err))
 		}
 	}(r)
 
+	urls := make([]*model.URL, 0, opts.Limit+1)
 	for r.Next() {
-		u := new(model.URL)
-		if err := r.Scan(&u.ID, &u.BaseURL, &u.User); err != nil {
+		u, err := scanURL(r)
+		if err != nil {
 			return nil, err
 		}
 		urls = append(urls, u)
 	}
-	if err := r.Err(); err != nil {
+	if err = r.Err(); err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
-
-	return urls, nil
+	return store.NewPage(urls, opts), nil
 }
 
 // URLsBulkCreate created records in db about urls which are provided in urls argument.
@@ -164,7 +451,7 @@ func (s *SQLStore) URLsBulkCreate(ctx context.Context, urls []*model.URL) ([]*mo
 	var response []*model.BatchCreateURLsResponse
 
 	// start transaction
-	tx, err := s.DB.Begin()
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -178,8 +465,11 @@ func (s *SQLStore) URLsBulkCreate(ctx context.Context, urls []*model.URL) ([]*mo
 
 	stmt, err := tx.PrepareContext(
 		ctx,
-		`INSERT INTO urls(short, original_url, created_by) VALUES ($1, $2, $3);`,
+		insertURLQuery,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("prepare: %w", err)
+	}
 
 	defer func() {
 		if err = stmt.Close(); err != nil && err != sql.ErrTxDone {
@@ -188,18 +478,40 @@ func (s *SQLStore) URLsBulkCreate(ctx context.Context, urls []*model.URL) ([]*mo
 	}()
 
 	for _, v := range urls {
-		if _, err = stmt.ExecContext(ctx, v.ID, v.BaseURL, v.User); err != nil {
-			pgERR := err.(*pq.Error)
-			if pgERR.Code != pgerrcode.UniqueViolation {
-				return nil, err
+		// failed statement aborts whole transaction, so every insert is done under savepoint which is
+		// rolled back on error. It allows to regenerate id or to look up existing url in same transaction.
+		err = insertURL(ctx, v, func() error {
+			args, err := s.insertArgs(v)
+			if err != nil {
+				return err
 			}
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT insert_url;`); err != nil {
+				return fmt.Errorf("savepoint: %w", err)
+			}
+			if _, err := stmt.ExecContext(ctx, args...); err != nil {
+				if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT insert_url;`); rbErr != nil {
+					return fmt.Errorf("rollback to savepoint: %w (insert: %v)", rbErr, err)
+				}
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT insert_url;`); err != nil {
+				return fmt.Errorf("release savepoint: %w", err)
+			}
+			return nil
+		})
+		switch {
+		case uniqueViolation(err) == urlsDedupKey:
 			if err = tx.QueryRowContext(
 				ctx,
-				`SELECT short FROM urls WHERE original_url = $1`,
-				v.BaseURL,
+				`SELECT short FROM urls WHERE dedup_key = $1 AND is_deleted = false;`,
+				s.dedupKey(v),
 			).Scan(&v.ID); err != nil {
 				return nil, err
 			}
+		case errors.Is(err, store.ErrAliasTaken):
+			return nil, fmt.Errorf("alias %q: %w", v.Alias, err)
+		case err != nil:
+			return nil, err
 		}
 		response = append(
 			response,
@@ -221,7 +533,7 @@ func (s *SQLStore) URLsBulkCreate(ctx context.Context, urls []*model.URL) ([]*mo
 func (s *SQLStore) URLsBulkDelete(urls []string, user string) error {
 	ids := pq.Array(urls)
 	if _, err := s.DB.Exec(
-		"UPDATE urls SET is_deleted=true WHERE created_by=$1 AND short = ANY($2);",
+		"UPDATE urls SET is_deleted=true, deleted_at=NOW(), updated_at=NOW() WHERE created_by=$1 AND short = ANY($2) AND is_deleted = false;",
 		user,
 		ids,
 	); err != nil {
@@ -230,6 +542,73 @@ func (s *SQLStore) URLsBulkDelete(urls []string, user string) error {
 	return nil
 }
 
+// URLsBulkRestore restores deleted urls with ids provided in ids argument which are created by user.
+// Restored url loses its dedup key if key is taken by live url or by other restored url with lesser id, so
+// restoration never violates uniqueness of dedup keys.
+func (s *SQLStore) URLsBulkRestore(ctx context.Context, ids []string, user string) (int64, error) {
+	res, err := s.DB.ExecContext(
+		ctx,
+		`UPDATE urls SET is_deleted = false, deleted_at = NULL, updated_at = NOW(),
+		dedup_key = CASE WHEN EXISTS (
+			SELECT 1 FROM urls o
+			WHERE o.dedup_key = urls.dedup_key AND o.short <> urls.short AND (
+				o.is_deleted = false OR (o.created_by = $1 AND o.short = ANY($2) AND o.short < urls.short)
+			)
+		) THEN NULL ELSE dedup_key END
+		WHERE created_by = $1 AND short = ANY($2) AND is_deleted = true;`,
+		user,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("urls bulk restore: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// PurgeDeleted removes urls which were deleted before provided time with their clicks, tags and history in
+// one transaction.
+// Urls which were deleted before deletion time was recorded are removed too.
+func (s *SQLStore) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			s.l.Error(fmt.Sprintf("purge deleted: unable to rollback: %v", err))
+		}
+	}()
+
+	// data of purged urls is stored in tables which are referencing urls by short id.
+	for _, table := range []string{"clicks", "url_tags", "url_history"} {
+		if _, err = tx.ExecContext(
+			ctx,
+			`DELETE FROM `+table+` WHERE short IN (
+				SELECT short FROM urls WHERE is_deleted = true AND (deleted_at IS NULL OR deleted_at < $1)
+			);`,
+			before,
+		); err != nil {
+			return 0, fmt.Errorf("purge %s: %w", table, err)
+		}
+	}
+	res, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM urls WHERE is_deleted = true AND (deleted_at IS NULL OR deleted_at < $1);`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge urls: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+	return n, nil
+}
+
 // Ping verifies a connection to the database is still alive, establishing a connection if necessary.
 func (s *SQLStore) Ping(ctx context.Context) error {
 	return s.DB.PingContext(ctx)
@@ -248,7 +627,8 @@ func (s *SQLStore) GetData(ctx context.Context) (*model.InternalStat, error) {
 	    COUNT(
 	        DISTINCT(created_by)
 	    )
-	FROM urls;
+	FROM urls
+	WHERE is_deleted = false AND (expires_at IS NULL OR expires_at > NOW());
 `
 	m := new(model.InternalStat)
 	if err := s.DB.QueryRowContext(ctx, q).Scan(&m.CountOfURLs, &m.CountOfUsers); err != nil {
@@ -256,3 +636,315 @@ func (s *SQLStore) GetData(ctx context.Context) (*model.InternalStat, error) {
 	}
 	return m, nil
 }
+
+// SweepExpired marks all expired urls as swept.
+func (s *SQLStore) SweepExpired(ctx context.Context) (int64, error) {
+	res, err := s.DB.ExecContext(
+		ctx,
+		`UPDATE urls SET is_swept = true, updated_at = NOW() WHERE is_swept = false AND expires_at <= NOW();`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("sweep expired: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// SaveClicks stores all clicks in one transaction.
+func (s *SQLStore) SaveClicks(ctx context.Context, clicks []*model.Click) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			s.l.Error(fmt.Sprintf("save clicks: unable to rollback: %v", err))
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO clicks(short, clicked_at, referer, user_agent, ip, variant) VALUES ($1, $2, $3, $4, $5, $6);`,
+	)
+	if err != nil {
+		return fmt.Errorf("prepare: %w", err)
+	}
+	defer func() {
+		if err = stmt.Close(); err != nil {
+			s.l.Error(fmt.Sprintf("save clicks: unable to close stmt: %v", err))
+		}
+	}()
+
+	for _, c := range clicks {
+		if _, err = stmt.ExecContext(ctx, c.URLID, c.Time, c.Referer, c.UserAgent, c.IP, c.Variant); err != nil {
+			return fmt.Errorf("insert click: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetLinkStats returns total and per-day counts of clicks.
+func (s *SQLStore) GetLinkStats(ctx context.Context, id string) (*model.LinkStats, error) {
+	stats := &model.LinkStats{
+		ID:    id,
+		Daily: []*model.DailyClicks{},
+	}
+	if err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT created_by FROM urls WHERE short = $1;`,
+		id,
+	).Scan(&stats.User); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.ErrNotFound
+		}
+		return nil, fmt.Errorf("query url: %w", err)
+	}
+
+	r, err := s.DB.QueryContext(
+		ctx,
+		`SELECT to_char(clicked_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, COUNT(*)
+		FROM clicks
+		WHERE short = $1
+		GROUP BY day
+		ORDER BY day;`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query clicks: %w", err)
+	}
+	defer func(r *sql.Rows) {
+		if err := r.Close(); err != nil {
+			s.l.Warn(fmt.Sprintf("closing rows: %v", err))
+		}
+	}(r)
+
+	for r.Next() {
+		d := new(model.DailyClicks)
+		if err = r.Scan(&d.Date, &d.Count); err != nil {
+			return nil, err
+		}
+		stats.Total += d.Count
+		stats.Daily = append(stats.Daily, d)
+	}
+	if err = r.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	if stats.Variants, err = s.variantClicks(ctx, id); err != nil {
+		return nil, fmt.Errorf("query variant clicks: %w", err)
+	}
+	return stats, nil
+}
+
+// variantClicks returns counts of clicks per split variant of url ordered by name of variant.
+func (s *SQLStore) variantClicks(ctx context.Context, id string) ([]*model.VariantClicks, error) {
+	r, err := s.DB.QueryContext(
+		ctx,
+		`SELECT variant, COUNT(*)
+		FROM clicks
+		WHERE short = $1 AND variant <> ''
+		GROUP BY variant
+		ORDER BY variant;`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(r *sql.Rows) {
+		if err := r.Close(); err != nil {
+			s.l.Warn(fmt.Sprintf("closing rows: %v", err))
+		}
+	}(r)
+
+	var variants []*model.VariantClicks
+	for r.Next() {
+		v := new(model.VariantClicks)
+		if err = r.Scan(&v.Name, &v.Count); err != nil {
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+	return variants, r.Err()
+}
+
+// UpdateURL applies options to url which is locked in transaction and saves it. Previous original url is
+// written to history in same transaction.
+func (s *SQLStore) UpdateURL(ctx context.Context, id string, opts ...model.URLOption) (*model.URL, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			s.l.Error(fmt.Sprintf("update url: unable to rollback: %v", err))
+		}
+	}()
+
+	u, err := scanURL(tx.QueryRowContext(ctx, `SELECT `+urlColumns+` FROM urls WHERE short=$1 FOR UPDATE;`, id))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, store.ErrNotFound
+	case err != nil:
+		return nil, err
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	}
+	prev := u.BaseURL
+	if err = u.Apply(opts...); err != nil {
+		return nil, err
+	}
+	u.UpdatedAt = model.Now()
+	changed := u.BaseURL != prev
+	rules, err := jsonArray(u.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("encode rules: %w", err)
+	}
+	variants, err := jsonArray(u.Variants)
+	if err != nil {
+		return nil, fmt.Errorf("encode variants: %w", err)
+	}
+	utm, err := utmJSON(u)
+	if err != nil {
+		return nil, fmt.Errorf("encode utm: %w", err)
+	}
+
+	// url with changed original url always gets its dedup key, so duplicate is reported by unique index.
+	// Otherwise url which doesn't take part in deduplication anymore loses its dedup key, and url which takes
+	// part in it again gets key back only if key isn't taken by other url meanwhile.
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE urls SET original_url = $2, raw_url = $3, redirect_code = $4, password_hash = $5, max_clicks = $6,
+		clicks_left = $7, rules = $8, variants = $9, passthrough = $10, utm = $11, updated_at = $12,
+		dedup_key = CASE
+			WHEN $14::BOOLEAN OR $13::VARCHAR IS NULL OR dedup_key IS NOT NULL THEN $13
+			WHEN NOT EXISTS (SELECT 1 FROM urls WHERE dedup_key = $13 AND is_deleted = false) THEN $13
+		END
+		WHERE short = $1;`,
+		id,
+		u.BaseURL,
+		u.RawURL,
+		u.Redirect(),
+		u.PasswordHash,
+		u.MaxClicks,
+		u.ClicksLeft,
+		rules,
+		variants,
+		u.Passthrough,
+		utm,
+		u.UpdatedAt,
+		s.dedupKey(u),
+		changed,
+	)
+	if uniqueViolation(err) == urlsDedupKey {
+		return nil, store.ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update url: %w", err)
+	}
+	if changed {
+		if _, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO url_history(short, original_url, replaced_at) VALUES ($1, $2, $3);`,
+			id,
+			prev,
+			u.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("insert history: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return u, nil
+}
+
+// TakeClick decrements remaining clicks of url under row lock, so concurrent redirects never take more
+// clicks than url has.
+func (s *SQLStore) TakeClick(ctx context.Context, id string) (*model.URL, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			s.l.Error(fmt.Sprintf("take click: unable to rollback: %v", err))
+		}
+	}()
+
+	u, err := scanURL(tx.QueryRowContext(ctx, `SELECT `+urlColumns+` FROM urls WHERE short=$1 FOR UPDATE;`, id))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, store.ErrNotFound
+	case err != nil:
+		return nil, err
+	case u.IsDeleted:
+		return nil, store.ErrIsDeleted
+	case u.IsExpired():
+		return nil, store.ErrExpired
+	case !u.IsLimited():
+		return u, nil
+	case u.IsExhausted():
+		return nil, store.ErrExhausted
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE urls SET clicks_left = clicks_left - 1 WHERE short = $1;`, id); err != nil {
+		return nil, fmt.Errorf("update url: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	u.ClicksLeft--
+	return u, nil
+}
+
+// GetHistory returns previous original urls of url from oldest to newest.
+func (s *SQLStore) GetHistory(ctx context.Context, id string) ([]*model.Destination, error) {
+	var exists bool
+	if err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT EXISTS(SELECT 1 FROM urls WHERE short = $1);`,
+		id,
+	).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, store.ErrNotFound
+	}
+
+	r, err := s.DB.QueryContext(
+		ctx,
+		`SELECT original_url, replaced_at FROM url_history WHERE short = $1 ORDER BY replaced_at, id;`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query db: %w", err)
+	}
+	defer func(r *sql.Rows) {
+		if err := r.Close(); err != nil {
+			s.l.Warn(fmt.Sprintf("closing rows: %v", err))
+		}
+	}(r)
+
+	var history []*model.Destination
+	for r.Next() {
+		d := new(model.Destination)
+		if err = r.Scan(&d.OriginalURL, &d.ReplacedAt); err != nil {
+			return nil, err
+		}
+		d.ReplacedAt = d.ReplacedAt.UTC()
+		history = append(history, d)
+	}
+	if err = r.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return history, nil
+}
+
+// NextSequence returns next value of urls_short_seq sequence.
+func (s *SQLStore) NextSequence(ctx context.Context) (n uint64, err error) {
+	if err = s.DB.QueryRowContext(ctx, `SELECT nextval('urls_short_seq');`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("next sequence: %w", err)
+	}
+	return n, nil
+}
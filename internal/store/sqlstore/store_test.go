@@ -2,10 +2,14 @@ package sqlstore
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -143,3 +147,154 @@ func TestSQLStore_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLStore_SyncDedupKeys(t *testing.T) {
+	ctx := context.Background()
+	s, td := TestStore(t)
+	defer td(t)
+	s.SetDedupScope(store.DedupUser)
+	require.NoError(t, s.SyncDedupKeys(ctx))
+
+	// same url of different users isn't duplicate in user scope.
+	first, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, first))
+	second, err := model.NewURL("https://example.org", "another")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, second))
+
+	// keys are recomputed for global scope: oldest url keeps key and newer one is not duplicate anymore.
+	s.SetDedupScope(store.DedupGlobal)
+	require.NoError(t, s.SyncDedupKeys(ctx))
+	dup, err := model.NewURL("https://example.org", "third")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	assert.Equal(t, first.ID, dup.ID)
+
+	// keys are recomputed for user scope again.
+	s.SetDedupScope(store.DedupUser)
+	require.NoError(t, s.SyncDedupKeys(ctx))
+	dup, err = model.NewURL("https://example.org", "another")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	assert.Equal(t, second.ID, dup.ID)
+}
+
+func TestSQLStore_Create_Deleted(t *testing.T) {
+	ctx := context.Background()
+	s, td := TestStore(t)
+	defer td(t)
+	deleted, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, deleted))
+	require.NoError(t, s.URLsBulkDelete([]string{deleted.ID}, "a"))
+
+	// deleted url isn't duplicate, so url is created anew.
+	u, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, u))
+	assert.NotEqual(t, deleted.ID, u.ID)
+
+	// restored url loses dedup key which is taken by live url.
+	restored, err := s.URLsBulkRestore(ctx, []string{deleted.ID}, "a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+	dup, err := model.NewURL("https://example.org", "a")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Create(ctx, dup), store.ErrAlreadyExists)
+	assert.Equal(t, u.ID, dup.ID)
+}
+
+func TestSQLStore_Create_ShortCollision(t *testing.T) {
+	storage, teardown := TestStore(t)
+	defer teardown(t)
+	ctx := context.Background()
+
+	taken, err := model.NewURL("https://example.org", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, storage.Create(ctx, taken))
+
+	// generator returns taken id first, so store must regenerate it.
+	ids := []string{taken.ID, "free", taken.ID, "free-bulk"}
+	gen := model.IDGeneratorFunc(func(context.Context) (string, error) {
+		id := ids[0]
+		ids = ids[1:]
+		return id, nil
+	})
+	u, err := model.NewURLWithGenerator(ctx, gen, "https://example.com", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, storage.Create(ctx, u))
+	assert.Equal(t, "free", u.ID)
+
+	bulk, err := model.NewURLWithGenerator(ctx, gen, "https://example.net", "marlo", "1")
+	require.NoError(t, err)
+	dup, err := model.NewURL("https://example.org", "marlo", "2")
+	require.NoError(t, err)
+	resp, err := storage.URLsBulkCreate(ctx, []*model.URL{bulk, dup})
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	assert.Equal(t, "free-bulk", resp[0].ShortURL)
+	assert.Equal(t, taken.ID, resp[1].ShortURL)
+
+	alias, err := model.NewURL("https://example.io", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, alias.Apply(model.WithAlias(taken.ID)))
+	assert.ErrorIs(t, storage.Create(ctx, alias), store.ErrAliasTaken)
+}
+
+func TestSQLStore_ListUserURLs(t *testing.T) {
+	storage, teardown := TestStore(t)
+	defer teardown(t)
+	ctx := context.Background()
+
+	var urls []*model.URL
+	for _, base := range []string{"https://example.org", "https://example.com", "https://example.net"} {
+		u, err := model.NewURL(base, "marlo")
+		require.NoError(t, err)
+		require.NoError(t, storage.Create(ctx, u))
+		urls = append(urls, u)
+	}
+
+	opts := store.ListOptions{Limit: 2, Sort: store.SortCreatedDesc}
+	page, err := storage.ListUserURLs(ctx, "marlo", opts)
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 2)
+	assert.Equal(t, urls[2].ID, page.URLs[0].ID)
+	assert.Equal(t, urls[2].CreatedAt, page.URLs[0].CreatedAt)
+	assert.Equal(t, urls[2].UpdatedAt, page.URLs[0].UpdatedAt)
+	assert.Equal(t, urls[1].ID, page.URLs[1].ID)
+
+	opts.Cursor = page.NextCursor
+	page, err = storage.ListUserURLs(ctx, "marlo", opts)
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	assert.Equal(t, urls[0].ID, page.URLs[0].ID)
+	assert.Empty(t, page.NextCursor)
+
+	page, err = storage.ListUserURLs(ctx, "marlo", store.ListOptions{Query: "EXAMPLE.COM"})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	assert.Equal(t, urls[1].ID, page.URLs[0].ID)
+
+	tagged, err := model.NewURL("https://example.io", "marlo")
+	require.NoError(t, err)
+	require.NoError(t, tagged.Apply(model.WithMetadata("Example", "note", []string{"news", "ads"})))
+	require.NoError(t, storage.Create(ctx, tagged))
+	page, err = storage.ListUserURLs(ctx, "marlo", store.ListOptions{Tag: "news"})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	assert.Equal(t, tagged.ID, page.URLs[0].ID)
+	assert.Equal(t, "Example", page.URLs[0].Title)
+	assert.Equal(t, "note", page.URLs[0].Note)
+	assert.Equal(t, []string{"ads", "news"}, page.URLs[0].Tags)
+}
+
+func TestUniqueViolation(t *testing.T) {
+	assert.Equal(t, "", uniqueViolation(nil))
+	assert.Equal(t, "", uniqueViolation(errors.New("some error")))
+	assert.Equal(t, "", uniqueViolation(&pq.Error{Code: pgerrcode.ForeignKeyViolation, Constraint: "fk"}))
+	assert.Equal(t, urlsShortKey, uniqueViolation(fmt.Errorf("wrapped: %w", &pq.Error{
+		Code:       pgerrcode.UniqueViolation,
+		Constraint: urlsShortKey,
+	})))
+}
@@ -32,7 +32,7 @@ func TestStore(t *testing.T) (*SQLStore, func(t *testing.T)) {
 	require.NoError(t, err, fmt.Sprintf("init db storage: %v", err))
 
 	return storage, func(t *testing.T) {
-		_, err = storage.DB.Exec("TRUNCATE urls CASCADE;")
+		_, err = storage.DB.Exec("TRUNCATE urls, store_settings CASCADE;")
 		assert.NoError(t, err, fmt.Sprintf("truncate db: %v", err))
 		require.NoError(t, storage.Close(), "close storage")
 	}
@@ -27,10 +27,15 @@ type ShortenerClient interface {
 	GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*GetLinkResponse, error)
 	CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*CreateLinkResponse, error)
 	DeleteMany(ctx context.Context, in *DeleteManyRequest, opts ...grpc.CallOption) (*DeleteManyResponse, error)
+	RestoreMany(ctx context.Context, in *RestoreManyRequest, opts ...grpc.CallOption) (*RestoreManyResponse, error)
+	PurgeDeleted(ctx context.Context, in *PurgeDeletedRequest, opts ...grpc.CallOption) (*PurgeDeletedResponse, error)
 	GetManyLinks(ctx context.Context, in *GetManyLinksRequest, opts ...grpc.CallOption) (*GetManyLinksResponse, error)
 	CreateManyLinks(ctx context.Context, in *CreateManyRequest, opts ...grpc.CallOption) (*CreateManyResponse, error)
 	CreateLinkJSON(ctx context.Context, in *CreateLinkJSONRequest, opts ...grpc.CallOption) (*CreateLinkJSONResponse, error)
 	GetInternalStats(ctx context.Context, in *GetInternalStatsRequest, opts ...grpc.CallOption) (*GetInternalStatsResponse, error)
+	GetLinkStats(ctx context.Context, in *GetLinkStatsRequest, opts ...grpc.CallOption) (*GetLinkStatsResponse, error)
+	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*UpdateLinkResponse, error)
+	GetLinkHistory(ctx context.Context, in *GetLinkHistoryRequest, opts ...grpc.CallOption) (*GetLinkHistoryResponse, error)
 }
 
 type shortenerClient struct {
@@ -86,6 +91,24 @@ func (c *shortenerClient) DeleteMany(ctx context.Context, in *DeleteManyRequest,
 	return out, nil
 }
 
+func (c *shortenerClient) RestoreMany(ctx context.Context, in *RestoreManyRequest, opts ...grpc.CallOption) (*RestoreManyResponse, error) {
+	out := new(RestoreManyResponse)
+	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/RestoreMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) PurgeDeleted(ctx context.Context, in *PurgeDeletedRequest, opts ...grpc.CallOption) (*PurgeDeletedResponse, error) {
+	out := new(PurgeDeletedResponse)
+	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/PurgeDeleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortenerClient) GetManyLinks(ctx context.Context, in *GetManyLinksRequest, opts ...grpc.CallOption) (*GetManyLinksResponse, error) {
 	out := new(GetManyLinksResponse)
 	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/GetManyLinks", in, out, opts...)
@@ -122,6 +145,33 @@ func (c *shortenerClient) GetInternalStats(ctx context.Context, in *GetInternalS
 	return out, nil
 }
 
+func (c *shortenerClient) GetLinkStats(ctx context.Context, in *GetLinkStatsRequest, opts ...grpc.CallOption) (*GetLinkStatsResponse, error) {
+	out := new(GetLinkStatsResponse)
+	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/GetLinkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*UpdateLinkResponse, error) {
+	out := new(UpdateLinkResponse)
+	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/UpdateLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) GetLinkHistory(ctx context.Context, in *GetLinkHistoryRequest, opts ...grpc.CallOption) (*GetLinkHistoryResponse, error) {
+	out := new(GetLinkHistoryResponse)
+	err := c.cc.Invoke(ctx, "/shortener.proto.Shortener/GetLinkHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServer is the server API for Shortener service.
 // All implementations must embed UnimplementedShortenerServer
 // for forward compatibility
@@ -131,10 +181,15 @@ type ShortenerServer interface {
 	GetLink(context.Context, *GetLinkRequest) (*GetLinkResponse, error)
 	CreateLink(context.Context, *CreateLinkRequest) (*CreateLinkResponse, error)
 	DeleteMany(context.Context, *DeleteManyRequest) (*DeleteManyResponse, error)
+	RestoreMany(context.Context, *RestoreManyRequest) (*RestoreManyResponse, error)
+	PurgeDeleted(context.Context, *PurgeDeletedRequest) (*PurgeDeletedResponse, error)
 	GetManyLinks(context.Context, *GetManyLinksRequest) (*GetManyLinksResponse, error)
 	CreateManyLinks(context.Context, *CreateManyRequest) (*CreateManyResponse, error)
 	CreateLinkJSON(context.Context, *CreateLinkJSONRequest) (*CreateLinkJSONResponse, error)
 	GetInternalStats(context.Context, *GetInternalStatsRequest) (*GetInternalStatsResponse, error)
+	GetLinkStats(context.Context, *GetLinkStatsRequest) (*GetLinkStatsResponse, error)
+	UpdateLink(context.Context, *UpdateLinkRequest) (*UpdateLinkResponse, error)
+	GetLinkHistory(context.Context, *GetLinkHistoryRequest) (*GetLinkHistoryResponse, error)
 	mustEmbedUnimplementedShortenerServer()
 }
 
@@ -157,6 +212,12 @@ func (UnimplementedShortenerServer) CreateLink(context.Context, *CreateLinkReque
 func (UnimplementedShortenerServer) DeleteMany(context.Context, *DeleteManyRequest) (*DeleteManyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMany not implemented")
 }
+func (UnimplementedShortenerServer) RestoreMany(context.Context, *RestoreManyRequest) (*RestoreManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMany not implemented")
+}
+func (UnimplementedShortenerServer) PurgeDeleted(context.Context, *PurgeDeletedRequest) (*PurgeDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeleted not implemented")
+}
 func (UnimplementedShortenerServer) GetManyLinks(context.Context, *GetManyLinksRequest) (*GetManyLinksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetManyLinks not implemented")
 }
@@ -169,6 +230,15 @@ func (UnimplementedShortenerServer) CreateLinkJSON(context.Context, *CreateLinkJ
 func (UnimplementedShortenerServer) GetInternalStats(context.Context, *GetInternalStatsRequest) (*GetInternalStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInternalStats not implemented")
 }
+func (UnimplementedShortenerServer) GetLinkStats(context.Context, *GetLinkStatsRequest) (*GetLinkStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkStats not implemented")
+}
+func (UnimplementedShortenerServer) UpdateLink(context.Context, *UpdateLinkRequest) (*UpdateLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLink not implemented")
+}
+func (UnimplementedShortenerServer) GetLinkHistory(context.Context, *GetLinkHistoryRequest) (*GetLinkHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkHistory not implemented")
+}
 func (UnimplementedShortenerServer) mustEmbedUnimplementedShortenerServer() {}
 
 // UnsafeShortenerServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +342,42 @@ func _Shortener_DeleteMany_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shortener_RestoreMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).RestoreMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shortener.proto.Shortener/RestoreMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).RestoreMany(ctx, req.(*RestoreManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_PurgeDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).PurgeDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shortener.proto.Shortener/PurgeDeleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).PurgeDeleted(ctx, req.(*PurgeDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Shortener_GetManyLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetManyLinksRequest)
 	if err := dec(in); err != nil {
@@ -344,6 +450,60 @@ func _Shortener_GetInternalStats_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shortener_GetLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).GetLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shortener.proto.Shortener/GetLinkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).GetLinkStats(ctx, req.(*GetLinkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_UpdateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).UpdateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shortener.proto.Shortener/UpdateLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).UpdateLink(ctx, req.(*UpdateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_GetLinkHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).GetLinkHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shortener.proto.Shortener/GetLinkHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).GetLinkHistory(ctx, req.(*GetLinkHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Shortener_ServiceDesc is the grpc.ServiceDesc for Shortener service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -371,6 +531,14 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMany",
 			Handler:    _Shortener_DeleteMany_Handler,
 		},
+		{
+			MethodName: "RestoreMany",
+			Handler:    _Shortener_RestoreMany_Handler,
+		},
+		{
+			MethodName: "PurgeDeleted",
+			Handler:    _Shortener_PurgeDeleted_Handler,
+		},
 		{
 			MethodName: "GetManyLinks",
 			Handler:    _Shortener_GetManyLinks_Handler,
@@ -387,6 +555,18 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInternalStats",
 			Handler:    _Shortener_GetInternalStats_Handler,
 		},
+		{
+			MethodName: "GetLinkStats",
+			Handler:    _Shortener_GetLinkStats_Handler,
+		},
+		{
+			MethodName: "UpdateLink",
+			Handler:    _Shortener_UpdateLink_Handler,
+		},
+		{
+			MethodName: "GetLinkHistory",
+			Handler:    _Shortener_GetLinkHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/shortener.proto",